@@ -0,0 +1,300 @@
+package yahoofinanceapi
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache stores raw response bodies of successful API calls.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, or false if it is missing or expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for the given time to live.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheTTL sets how long responses of each endpoint stay cached. A zero duration disables caching for that endpoint.
+type CacheTTL struct {
+	History       time.Duration // Chart data that may still change, e.g. intraday bars or ranges ending today
+	ClosedHistory time.Duration // Daily, weekly or monthly chart data whose period ended before today
+	Info          time.Duration // quoteSummary data such as the latest quote
+	Options       time.Duration // Option chains
+	Search        time.Duration // Symbol search results
+}
+
+// DefaultCacheTTL returns short TTLs for live data and a long one for history that can no longer change.
+func DefaultCacheTTL() CacheTTL {
+	return CacheTTL{
+		History:       time.Minute,
+		ClosedHistory: 24 * time.Hour,
+		Info:          15 * time.Second,
+		Options:       time.Minute,
+		Search:        time.Hour,
+	}
+}
+
+// CacheStats counts cache lookups made by a Client.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// cacheStats is the concurrency-safe counterpart of CacheStats.
+type cacheStats struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// WithCache caches successful responses in cache, for as long as ttl allows per endpoint.
+func WithCache(cache Cache, ttl CacheTTL) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// CacheStats returns the number of cache hits and misses since the Client was created.
+func (c *Client) CacheStats() CacheStats {
+	return CacheStats{Hits: c.stats.hits.Load(), Misses: c.stats.misses.Load()}
+}
+
+// ttlFor returns how long the response to a request for endpoint with params may be cached.
+func (t CacheTTL) ttlFor(endpoint string, params url.Values) time.Duration {
+	switch {
+	case strings.Contains(endpoint, "/finance/chart/"):
+		if isClosedHistory(params, time.Now()) {
+			return t.ClosedHistory
+		}
+		return t.History
+	case strings.Contains(endpoint, "/finance/quoteSummary/"):
+		return t.Info
+	case strings.Contains(endpoint, "/finance/options/"):
+		return t.Options
+	case strings.Contains(endpoint, "/finance/search"):
+		return t.Search
+	}
+	return 0
+}
+
+// isClosedHistory reports whether chart params ask for daily or longer bars of a period that ended before today.
+func isClosedHistory(params url.Values, now time.Time) bool {
+	if !isDailyOrLonger(Interval(params.Get("interval"))) {
+		return false
+	}
+	if params.Get("range") != "" {
+		return false
+	}
+	end, err := strconv.ParseInt(params.Get("period2"), 10, 64)
+	if err != nil {
+		return false
+	}
+	year, month, day := now.UTC().Date()
+	return time.Unix(end, 0).Before(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// cacheKey identifies a request independently of the session crumb.
+// period1 and period2 are left out when a range is given, since Yahoo answers by range then.
+func cacheKey(endpoint string, params url.Values) string {
+	if params.Get("range") != "" {
+		copied := url.Values{}
+		for key, values := range params {
+			copied[key] = values
+		}
+		copied.Del("period1")
+		copied.Del("period2")
+		params = copied
+	}
+	return endpoint + "?" + params.Encode()
+}
+
+// getCached serves the request from the cache if possible. On a miss it calls fetch and caches a successful response.
+func (c *Client) getCached(ctx context.Context, endpoint string, params url.Values, fetch func() (*http.Response, error)) (*http.Response, error) {
+	ttl := c.cacheTTL.ttlFor(endpoint, params)
+	if c.cache == nil || ttl <= 0 {
+		return fetch()
+	}
+
+	key := cacheKey(endpoint, params)
+	if body, ok := c.cache.Get(key); ok {
+		c.stats.hits.Add(1)
+		c.logger.Debug("Serving Yahoo Finance API response from cache", requestAttrs(ctx, endpoint)...)
+		return cachedResponse(ctx, key, body)
+	}
+	c.stats.misses.Add(1)
+
+	resp, err := fetch()
+	if err != nil || !isSuccess(resp.StatusCode) {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	c.cache.Set(key, body, ttl)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// cachedResponse wraps a cached body in a 200 response for the request identified by key.
+func cachedResponse(ctx context.Context, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used entry once it is full.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // front is most recently used
+	entries    map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding at most maxEntries responses. A maxEntries of 0 means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires := time.Now().Add(ttl)
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expires = expires
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of cached entries, including expired ones not yet evicted.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// DiskCache is a Cache that keeps one file per response in a directory, so cached data survives restarts.
+type DiskCache struct {
+	dir string
+}
+
+type diskEntry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+	Value   []byte    `json:"value"`
+}
+
+// NewDiskCache creates a DiskCache in dir, creating the directory if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the file that stores key.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	if time.Now().After(entry.Expires) {
+		os.Remove(d.path(key))
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (d *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	data, err := json.Marshal(diskEntry{Key: key, Expires: time.Now().Add(ttl), Value: value})
+	if err != nil {
+		return
+	}
+	// Write to a temporary file first so concurrent readers never see a partial entry.
+	tmp, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
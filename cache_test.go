@@ -0,0 +1,148 @@
+package yahoofinanceapi
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheLRU(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected 'a' to be cached")
+	}
+
+	// 'b' is now the least recently used entry and gets evicted.
+	cache.Set("c", []byte("3"), time.Minute)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected 'b' to be evicted")
+	}
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Errorf("Expected 'a' to survive eviction, got %q (ok=%v)", value, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache(0)
+	cache.Set("a", []byte("1"), -time.Second)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected expired entry to be missing")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected expired entry to be removed, got %d entries", cache.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	cache.Set("key", []byte(`{"chart":{}}`), time.Minute)
+	cache.Set("expired", []byte("old"), -time.Second)
+
+	// A second instance sees the entries written by the first.
+	reopened, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	if value, ok := reopened.Get("key"); !ok || string(value) != `{"chart":{}}` {
+		t.Errorf("Expected cached value, got %q (ok=%v)", value, ok)
+	}
+	if _, ok := reopened.Get("expired"); ok {
+		t.Error("Expected expired entry to be missing")
+	}
+	if _, ok := reopened.Get("missing"); ok {
+		t.Error("Expected missing entry")
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("Expected expired file to be removed, found %d files", len(files))
+	}
+}
+
+func TestCacheTTLFor(t *testing.T) {
+	ttl := CacheTTL{History: time.Minute, ClosedHistory: time.Hour, Info: time.Second, Options: 2 * time.Second, Search: 3 * time.Second}
+	closed := url.Values{"interval": {"1d"}, "period1": {"1704067200"}, "period2": {"1706745600"}}
+	open := url.Values{"interval": {"1d"}, "range": {"1mo"}}
+	intraday := url.Values{"interval": {"1m"}, "period1": {"1704067200"}, "period2": {"1704153600"}}
+
+	tests := []struct {
+		endpoint string
+		params   url.Values
+		want     time.Duration
+	}{
+		{"https://host/v8/finance/chart/AAPL", closed, time.Hour},
+		{"https://host/v8/finance/chart/AAPL", open, time.Minute},
+		{"https://host/v8/finance/chart/AAPL", intraday, time.Minute},
+		{"https://host/v10/finance/quoteSummary/AAPL", nil, time.Second},
+		{"https://host/v7/finance/options/AAPL", nil, 2 * time.Second},
+		{"https://host/v1/finance/search", nil, 3 * time.Second},
+		{"https://host/v1/test/getcrumb", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := ttl.ttlFor(tt.endpoint, tt.params); got != tt.want {
+			t.Errorf("ttlFor(%s, %v) = %v, want %v", tt.endpoint, tt.params, got, tt.want)
+		}
+	}
+}
+
+func TestCacheKeyIgnoresPeriodsForRanges(t *testing.T) {
+	a := cacheKey("chart", url.Values{"range": {"1mo"}, "interval": {"1d"}, "period2": {"1"}})
+	b := cacheKey("chart", url.Values{"range": {"1mo"}, "interval": {"1d"}, "period2": {"2"}})
+	if a != b {
+		t.Errorf("Expected identical keys, got %q and %q", a, b)
+	}
+}
+
+func TestClientServesRepeatedRequestsFromCache(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/v10/finance/quoteSummary/MISSING" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found"}}}`))
+			return
+		}
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL","shortName":"Apple Inc."}}],"error":null}}`))
+	})
+
+	c := newTestClient(srv, WithCache(NewMemoryCache(10), DefaultCacheTTL()))
+	for i := 0; i < 3; i++ {
+		info, err := NewTickerWithClient("AAPL", c).Info()
+		if err != nil {
+			t.Fatalf("Info returned error: %v", err)
+		}
+		if info.ShortName != "Apple Inc." {
+			t.Errorf("Expected ShortName 'Apple Inc.', got %q", info.ShortName)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", n)
+	}
+
+	// Errors are never cached.
+	for i := 0; i < 2; i++ {
+		if _, err := NewTickerWithClient("MISSING", c).Info(); err == nil {
+			t.Fatal("Expected error for missing symbol")
+		}
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("Expected failed requests to reach the server every time, got %d requests", n)
+	}
+
+	stats := c.CacheStats()
+	if stats.Hits != 2 || stats.Misses != 3 {
+		t.Errorf("Expected 2 hits and 3 misses, got %+v", stats)
+	}
+}
@@ -0,0 +1,186 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// intradayLimits are Yahoo's limits for intraday intervals: the longest window a single chart
+// request may span, and how far back the bars are kept.
+var intradayLimits = map[Interval]struct{ window, retention time.Duration }{
+	Interval1m:  {7 * day, 30 * day},
+	Interval2m:  {60 * day, 60 * day},
+	Interval5m:  {60 * day, 60 * day},
+	Interval15m: {60 * day, 60 * day},
+	Interval30m: {60 * day, 60 * day},
+	Interval90m: {60 * day, 60 * day},
+	Interval60m: {730 * day, 730 * day},
+	Interval1h:  {730 * day, 730 * day},
+}
+
+// historyChunks splits a Start/End query for an intraday interval into queries that Yahoo serves
+// in one request each. It returns nil for range queries and daily or longer intervals, and an error
+// matching ErrBeyondRetention if the query starts before the oldest bars Yahoo keeps.
+func historyChunks(query HistoryQuery, now time.Time) ([]HistoryQuery, error) {
+	limits, ok := intradayLimits[query.Interval]
+	if !ok || query.Range != "" {
+		return nil, nil
+	}
+	from, to := query.Start, query.End
+	if from.IsZero() {
+		return nil, nil
+	}
+	if oldest := now.Add(-limits.retention); from.Before(oldest) {
+		return nil, fmt.Errorf("%w: %s bars are kept for %d days, but the history starts on %s",
+			ErrBeyondRetention, query.Interval, int(limits.retention/day), from.UTC().Format("2006-01-02"))
+	}
+
+	var chunks []HistoryQuery
+	for chunkStart := from; chunkStart.Before(to); chunkStart = chunkStart.Add(limits.window) {
+		chunkEnd := chunkStart.Add(limits.window)
+		if chunkEnd.After(to) {
+			chunkEnd = to
+		}
+		chunk := query
+		chunk.Start, chunk.End = chunkStart, chunkEnd
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// getHistoryChunks fetches the chunks of a history, up to concurrency at a time, and merges them.
+func (h *History) getHistoryChunks(ctx context.Context, symbol string, chunks []HistoryQuery, concurrency int) (YahooHistoryRespose, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make([]YahooHistoryRespose, len(chunks))
+	var mu sync.Mutex // guards firstErr and the cancellation
+	var firstErr error
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := h.fetchHistory(ctx, symbol, chunk)
+			if err == nil {
+				responses[i] = resp
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			// Chunks still in flight fail once the first error cancels them; report the cause.
+			if firstErr == nil && ctx.Err() == nil {
+				firstErr = fmt.Errorf("history chunk %d of %d: %w", i+1, len(chunks), err)
+			}
+			// The history is useless without any of its chunks.
+			cancel()
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return YahooHistoryRespose{}, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return YahooHistoryRespose{}, err
+	}
+	h.client.logger.Debug("Merged history chunks", "symbol", symbol, "chunks", len(chunks))
+	return mergeHistory(responses), nil
+}
+
+// mergeHistory stitches the chart results of consecutive chunks into one. Bars at timestamps
+// that several chunks return are kept once. The meta data is that of the most recent chunk.
+func mergeHistory(responses []YahooHistoryRespose) YahooHistoryRespose {
+	var merged YahooHistoryResult
+	var quote YahooQuote
+	var adjClose YahooAdjClose
+	hasAdjClose := false
+	seen := make(map[int64]bool)
+	for _, resp := range responses {
+		result := resp.Chart.Result[0]
+		merged.Meta = result.Meta
+		var chunkQuote YahooQuote
+		if len(result.Indicators.Quote) > 0 {
+			chunkQuote = result.Indicators.Quote[0]
+		}
+		var chunkAdjClose []*float64
+		if len(result.Indicators.AdjClose) > 0 {
+			chunkAdjClose = result.Indicators.AdjClose[0].AdjClose
+			hasAdjClose = true
+		}
+		for i, timestamp := range result.Timestamp {
+			if seen[timestamp] {
+				continue
+			}
+			seen[timestamp] = true
+			merged.Timestamp = append(merged.Timestamp, timestamp)
+			quote.Open = append(quote.Open, nullableAt(chunkQuote.Open, i))
+			quote.High = append(quote.High, nullableAt(chunkQuote.High, i))
+			quote.Low = append(quote.Low, nullableAt(chunkQuote.Low, i))
+			quote.Close = append(quote.Close, nullableAt(chunkQuote.Close, i))
+			quote.Volume = append(quote.Volume, nullableAt(chunkQuote.Volume, i))
+			adjClose.AdjClose = append(adjClose.AdjClose, nullableAt(chunkAdjClose, i))
+		}
+		merged.Events = mergeEvents(merged.Events, result.Events)
+	}
+	// Trading periods of earlier chunks are lost with their meta data; collect them all.
+	merged.Meta.TradingPeriods = YahooTradingPeriods{}
+	for _, resp := range responses {
+		periods := resp.Chart.Result[0].Meta.TradingPeriods
+		merged.Meta.TradingPeriods.Pre = append(merged.Meta.TradingPeriods.Pre, periods.Pre...)
+		merged.Meta.TradingPeriods.Regular = append(merged.Meta.TradingPeriods.Regular, periods.Regular...)
+		merged.Meta.TradingPeriods.Post = append(merged.Meta.TradingPeriods.Post, periods.Post...)
+	}
+	merged.Indicators.Quote = []YahooQuote{quote}
+	if hasAdjClose {
+		merged.Indicators.AdjClose = []YahooAdjClose{adjClose}
+	}
+	return YahooHistoryRespose{Chart: YahooChart{Result: []YahooHistoryResult{merged}}}
+}
+
+// mergeEvents adds the events of b to a, allocating a if needed.
+func mergeEvents(a, b *YahooEvents) *YahooEvents {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		a = &YahooEvents{}
+	}
+	a.Dividends = mergeMaps(a.Dividends, b.Dividends)
+	a.Splits = mergeMaps(a.Splits, b.Splits)
+	a.CapitalGains = mergeMaps(a.CapitalGains, b.CapitalGains)
+	return a
+}
+
+func mergeMaps[V any](a, b map[string]V) map[string]V {
+	if len(b) == 0 {
+		return a
+	}
+	if a == nil {
+		a = make(map[string]V, len(b))
+	}
+	for k, v := range b {
+		a[k] = v
+	}
+	return a
+}
+
+// nullableAt returns the i-th value of a nullable chart array, or nil if it is missing.
+func nullableAt[T any](values []*T, i int) *T {
+	if i >= len(values) {
+		return nil
+	}
+	return values[i]
+}
@@ -0,0 +1,170 @@
+package yahoofinanceapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHistoryChunks(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	start, end := now.AddDate(0, 0, -20), now
+
+	chunks, err := historyChunks(HistoryQuery{Interval: Interval1m, Start: start, End: end}, now)
+	if err != nil {
+		t.Fatalf("historyChunks returned error: %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 20 days of 1m bars in 3 chunks, got %d", len(chunks))
+	}
+	if !chunks[0].Start.Equal(start) || !chunks[2].End.Equal(end) {
+		t.Errorf("Expected the chunks to span the query, got %s to %s", chunks[0].Start, chunks[2].End)
+	}
+	for i := 1; i < len(chunks); i++ {
+		if !chunks[i].Start.Equal(chunks[i-1].End) {
+			t.Errorf("Chunk %d starts at %s, not where chunk %d ends at %s", i, chunks[i].Start, i-1, chunks[i-1].End)
+		}
+	}
+
+	for _, query := range []HistoryQuery{
+		{Interval: "1d", Start: start.AddDate(-5, 0, 0), End: end},
+		{Interval: "1m", Range: "5d"},
+		{Interval: "5m", Start: start, End: end},
+	} {
+		if chunks, err := historyChunks(query, now); err != nil || len(chunks) > 1 {
+			t.Errorf("Expected %+v to need a single request, got %d chunks (err=%v)", query, len(chunks), err)
+		}
+	}
+
+	_, err = historyChunks(HistoryQuery{Interval: "1m", Start: now.AddDate(0, 0, -40), End: end}, now)
+	if !errors.Is(err, ErrBeyondRetention) || !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrBeyondRetention and ErrInvalidRange, got %v", err)
+	}
+}
+
+// chunkHandler serves a 1m chart with a bar every 6 hours in [period1, period2], so consecutive
+// chunks overlap in the bar at their boundary.
+func chunkHandler(requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		start, _ := strconv.ParseInt(r.URL.Query().Get("period1"), 10, 64)
+		end, _ := strconv.ParseInt(r.URL.Query().Get("period2"), 10, 64)
+		result := YahooHistoryResult{Meta: YahooMeta{Symbol: "AAPL", DataGranularity: "1m"}}
+		var quote YahooQuote
+		const step = 6 * 60 * 60
+		for ts := start - start%step + step; ts <= end; ts += step {
+			price, volume := float64(ts%1000), ts%1000
+			result.Timestamp = append(result.Timestamp, ts)
+			quote.Open = append(quote.Open, &price)
+			quote.High = append(quote.High, &price)
+			quote.Low = append(quote.Low, &price)
+			quote.Close = append(quote.Close, &price)
+			quote.Volume = append(quote.Volume, &volume)
+		}
+		result.Indicators.Quote = []YahooQuote{quote}
+		json.NewEncoder(w).Encode(YahooHistoryRespose{Chart: YahooChart{Result: []YahooHistoryResult{result}}})
+	}
+}
+
+func TestHistorySeriesChunked(t *testing.T) {
+	var requests atomic.Int32
+	srv := newTestServer(t, chunkHandler(&requests))
+	ticker := NewTickerWithClient("AAPL", newTestClient(srv))
+
+	end := time.Now().Truncate(time.Hour)
+	start := end.AddDate(0, 0, -20)
+	query := HistoryQuery{
+		Interval:    "1m",
+		Start:       start,
+		End:         end,
+		Concurrency: 3,
+	}
+	series, err := ticker.HistorySeries(query)
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("Expected 3 chunk requests, got %d", n)
+	}
+	if series.Len() < 20*4 {
+		t.Errorf("Expected at least %d bars, got %d", 20*4, series.Len())
+	}
+	for i := 1; i < series.Len(); i++ {
+		if !series.Bars[i-1].Time.Before(series.Bars[i].Time) {
+			t.Fatalf("Expected ordered bars without duplicates, got %v then %v", series.Bars[i-1].Time, series.Bars[i].Time)
+		}
+	}
+
+	query.Start = end.AddDate(0, 0, -45)
+	requests.Store(0)
+	if _, err := ticker.HistorySeries(query); !errors.Is(err, ErrBeyondRetention) {
+		t.Errorf("Expected ErrBeyondRetention, got %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("Expected no requests beyond the retention, got %d", n)
+	}
+}
+
+func TestHistoryChunkError(t *testing.T) {
+	var requests atomic.Int32
+	ok := chunkHandler(&requests)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Load() == 1 {
+			requests.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`))
+			return
+		}
+		ok(w, r)
+	})
+	client := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	end := time.Now()
+	_, err := NewTickerWithClient("AAPL", client).HistorySeries(HistoryQuery{
+		Interval: "1m",
+		Start:    end.AddDate(0, 0, -10),
+		End:      end,
+	})
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected the failed chunk's error, got %v", err)
+	}
+}
+
+func TestHistoryChunkErrorWhileOthersInFlight(t *testing.T) {
+	var requests atomic.Int32
+	ok := chunkHandler(&requests)
+	end := time.Now().Truncate(time.Second)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("period2") == strconv.FormatInt(end.Unix(), 10) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`))
+			return
+		}
+		// The earlier chunks are still in flight when the last one fails.
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(500 * time.Millisecond):
+		}
+		ok(w, r)
+	})
+	client := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	_, err := NewTickerWithClient("AAPL", client).HistorySeries(HistoryQuery{
+		Interval:    "1m",
+		Start:       end.AddDate(0, 0, -20),
+		End:         end,
+		Concurrency: 3,
+	})
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Fatalf("Expected the failed chunk's error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "history chunk 3 of 3") {
+		t.Errorf("Expected the error of the last chunk, got %v", err)
+	}
+}
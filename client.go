@@ -1,6 +1,7 @@
 package yahoofinanceapi
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -8,12 +9,122 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 )
 
+// Client sends requests to Yahoo Finance and manages the session cookies and crumb they need.
+// A Client is safe for concurrent use by multiple goroutines.
 type Client struct {
-	client  *http.Client
+	client    *http.Client
+	baseURL   string
+	cookieURL string
+	userAgent string
+	retry     RetryPolicy
+	logger    *slog.Logger
+
+	mu      sync.Mutex // guards cookies and crumb
 	cookies []*http.Cookie
 	crumb   string
+	// bootstrap holds a token while a goroutine fetches the cookies and crumb,
+	// so concurrent requests wait for that single bootstrap instead of starting their own.
+	bootstrap chan struct{}
+
+	limiter      *RateLimiter
+	hostLimiters map[string]*RateLimiter
+
+	cache    Cache
+	cacheTTL CacheTTL
+	stats    cacheStats
+
+	middleware []Middleware
+}
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient uses a copy of the given http.Client for all requests.
+// Options applied after it (WithTimeout, WithTransport, WithProxy) modify the copy, never the original.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		if hc == nil {
+			return
+		}
+		copied := *hc
+		c.client = &copied
+	}
+}
+
+// WithTimeout sets the overall timeout of every HTTP request made by the Client.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.client.Timeout = timeout
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.client.Transport = transport
+	}
+}
+
+// WithProxy routes every request through the given proxy URL.
+// It requires the Client's transport to be an *http.Transport (the default).
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		var transport *http.Transport
+		switch t := c.client.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = t.Clone()
+		default:
+			return
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		c.client.Transport = transport
+	}
+}
+
+// WithBaseURL overrides the Yahoo Finance API host, e.g. to point the Client at a local test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithCookieURL overrides the URL the Client visits to obtain its session cookies.
+func WithCookieURL(cookieURL string) ClientOption {
+	return func(c *Client) {
+		c.cookieURL = cookieURL
+	}
+}
+
+// WithUserAgent sends a fixed User-Agent header instead of rotating through USER_AGENTS.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// NewClient creates a Client with its own HTTP client, cookies and crumb.
+// Without options it behaves like the shared client used by NewTicker.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		client:    &http.Client{},
+		baseURL:   BASE_URL,
+		cookieURL: COOKIE_URL,
+		cookies:   []*http.Cookie{},
+		crumb:     "",
+		retry:     DefaultRetryPolicy(),
+		logger:    slog.New(discardHandler{}),
+		bootstrap: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.applyMiddleware()
+	return c
 }
 
 var instance *Client
@@ -21,74 +132,183 @@ var once sync.Once
 
 func getClient() *Client {
 	once.Do(func() {
-		instance = &Client{client: &http.Client{}, cookies: []*http.Cookie{}, crumb: ""}
+		instance = NewClient()
 	})
 	return instance
 }
 
 func (c *Client) Get(url string, params url.Values) (*http.Response, error) {
-	c.getCrumb()
-	return c.get(url, params)
+	return c.GetContext(context.Background(), url, params)
 }
 
-func (c *Client) get(url string, params url.Values) (*http.Response, error) {
-	if c.crumb != "" {
-		params.Add("crumb", c.crumb)
+// GetContext is like Get but carries ctx through the cookie and crumb bootstrap as well as
+// the data request, so cancelling ctx aborts whichever of them is in flight.
+//
+// Requests rejected with 401, 429 or a 5xx status are retried according to the Client's
+// RetryPolicy. A 401 additionally drops the cached cookies and crumb so the next attempt
+// bootstraps a fresh session. Once attempts run out the last response is returned as is.
+//
+// If the Client has a Cache, successful responses are cached and served from it.
+func (c *Client) GetContext(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	return c.getCached(ctx, endpoint, params, func() (*http.Response, error) {
+		return c.getWithRetry(ctx, endpoint, params)
+	})
+}
+
+// getWithRetry sends the request, bootstrapping the session and retrying as described on GetContext.
+func (c *Client) getWithRetry(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		c.getCrumb(ctx)
+		_, crumb := c.session()
+		resp, err := c.get(ctx, endpoint, params)
+		if attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		if err == nil && !shouldRetry(resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay := c.retry.backoff(attempt, resp)
+		attrs := append(requestAttrs(ctx, endpoint), "attempt", attempt)
+		if resp != nil {
+			if resp.StatusCode == http.StatusUnauthorized {
+				// Yahoo rotated the crumb or expired the session; start over right away.
+				c.resetSession(crumb)
+				delay = 0
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			attrs = append(attrs, "status", resp.StatusCode)
+		} else {
+			attrs = append(attrs, "err", err)
+		}
+		c.logger.Warn("Retrying Yahoo Finance API request", append(attrs, "delay", delay)...)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// session returns the current cookies and crumb.
+func (c *Client) session() ([]*http.Cookie, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cookies, c.crumb
+}
+
+// resetSession forgets the cookies and crumb so the next request bootstraps them again.
+// It does nothing if another goroutine already replaced the rejected crumb.
+func (c *Client) resetSession(rejectedCrumb string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.crumb != rejectedCrumb {
+		return
+	}
+	c.cookies = []*http.Cookie{}
+	c.crumb = ""
+}
+
+// get sends a single GET request. params is copied, never modified.
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	cookies, crumb := c.session()
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	if crumb != "" {
+		query.Set("crumb", crumb)
 	}
-	url = fmt.Sprintf("%s?%s", url, params.Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	url := fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	attrs := requestAttrs(ctx, endpoint)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		slog.Error("Failed to create request", "err", err)
+		c.logger.Error("Failed to create request", append(attrs, "err", err)...)
 		return nil, err
 	}
 
-	for _, cookie := range c.cookies {
+	if err := c.waitRateLimit(ctx, req.URL.Host); err != nil {
+		return nil, err
+	}
+
+	for _, cookie := range cookies {
 		req.AddCookie(cookie)
 	}
-	req.Header.Set("User-Agent", USER_AGENTS[rand.Intn(len(USER_AGENTS))])
+	req.Header.Set("User-Agent", c.getUserAgent())
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	latency := time.Since(start)
 	if err != nil {
-		slog.Error("Failed to get data from Yahoo Finance API", "err", err)
+		c.logger.Error("Failed to get data from Yahoo Finance API", append(attrs, "latency", latency, "err", err)...)
 		return nil, err
 	}
+	c.logger.Debug("Yahoo Finance API request", append(attrs, "status", resp.StatusCode, "latency", latency)...)
 
 	return resp, nil
 }
 
-func (c *Client) getCookie() {
-	if len(c.cookies) > 0 {
+func (c *Client) getUserAgent() string {
+	if c.userAgent != "" {
+		return c.userAgent
+	}
+	return USER_AGENTS[rand.Intn(len(USER_AGENTS))]
+}
+
+func (c *Client) getCookie(ctx context.Context) {
+	if cookies, _ := c.session(); len(cookies) > 0 {
 		return
 	}
 
-	endpoint := "https://fc.yahoo.com"
-	resp, err := c.get(endpoint, url.Values{})
+	resp, err := c.get(contextWithEndpoint(ctx, "cookie"), c.cookieURL, url.Values{})
 	if err != nil {
-		slog.Error("Failed to get cookie", "err", err)
+		c.logger.Error("Failed to get cookie", "err", err)
 		return
 	}
+	defer resp.Body.Close()
 
+	c.mu.Lock()
 	c.cookies = resp.Cookies()
+	c.mu.Unlock()
 }
 
-func (c *Client) getCrumb() {
-	if c.crumb != "" {
+// getCrumb bootstraps the session if needed. Only one goroutine bootstraps at a time;
+// the others wait for it and then reuse its crumb.
+func (c *Client) getCrumb(ctx context.Context) {
+	if _, crumb := c.session(); crumb != "" {
 		return
 	}
 
-	c.getCookie()
-	endpoint := fmt.Sprintf("%s/v1/test/getcrumb", BASE_URL)
-	resp, err := c.get(endpoint, url.Values{})
+	select {
+	case c.bootstrap <- struct{}{}:
+		defer func() { <-c.bootstrap }()
+	case <-ctx.Done():
+		return
+	}
+	if _, crumb := c.session(); crumb != "" {
+		return
+	}
+
+	c.getCookie(ctx)
+	endpoint := fmt.Sprintf("%s/v1/test/getcrumb", c.baseURL)
+	resp, err := c.get(ctx, endpoint, url.Values{})
 	if err != nil {
-		slog.Error("Failed to get crumb", "err", err)
+		c.logger.Error("Failed to get crumb", "err", err)
 		return
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		slog.Error("Error reading response body:", "err", err)
+		c.logger.Error("Failed to read crumb response body", "err", err)
+		return
+	}
+	if !isSuccess(resp.StatusCode) {
+		// Keep the session empty so the next request bootstraps again instead of sending an error page as crumb.
+		c.logger.Error("Failed to get crumb", "status", resp.StatusCode, "body", bodySnippet(body))
 		return
 	}
 
+	c.mu.Lock()
 	c.crumb = string(body)
+	c.mu.Unlock()
 }
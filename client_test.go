@@ -0,0 +1,225 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testCrumb = "test-crumb"
+
+// newTestServer starts a server that serves the cookie and crumb bootstrap endpoints
+// and delegates every other request to handler.
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cookie", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "A3", Value: "session"})
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/v1/test/getcrumb", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testCrumb))
+	})
+	mux.HandleFunc("/", handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestClient returns a Client pointed at srv.
+func newTestClient(srv *httptest.Server, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{WithBaseURL(srv.URL), WithCookieURL(srv.URL + "/cookie")}, opts...)
+	return NewClient(opts...)
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	c := NewClient()
+	if c.baseURL != BASE_URL {
+		t.Errorf("Expected base URL %s, got %s", BASE_URL, c.baseURL)
+	}
+	if c.cookieURL != COOKIE_URL {
+		t.Errorf("Expected cookie URL %s, got %s", COOKIE_URL, c.cookieURL)
+	}
+	if c == getClient() {
+		t.Error("NewClient returned the shared client")
+	}
+}
+
+func TestWithHTTPClientDoesNotMutateOriginal(t *testing.T) {
+	hc := &http.Client{}
+	c := NewClient(WithHTTPClient(hc), WithTimeout(5*time.Second))
+	if c.client.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout 5s, got %v", c.client.Timeout)
+	}
+	if hc.Timeout != 0 {
+		t.Error("WithTimeout modified the caller's http.Client")
+	}
+}
+
+func TestClientSendsCrumbAndCookie(t *testing.T) {
+	var gotCrumb, gotCookie, gotUserAgent string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotCrumb = r.URL.Query().Get("crumb")
+		if cookie, err := r.Cookie("A3"); err == nil {
+			gotCookie = cookie.Value
+		}
+		gotUserAgent = r.UserAgent()
+		w.Write([]byte("{}"))
+	})
+
+	c := newTestClient(srv, WithUserAgent("test-agent"))
+	resp, err := c.Get(srv.URL+"/v8/finance/chart/AAPL", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotCrumb != testCrumb {
+		t.Errorf("Expected crumb %q, got %q", testCrumb, gotCrumb)
+	}
+	if gotCookie != "session" {
+		t.Errorf("Expected cookie 'session', got %q", gotCookie)
+	}
+	if gotUserAgent != "test-agent" {
+		t.Errorf("Expected User-Agent 'test-agent', got %q", gotUserAgent)
+	}
+}
+
+func TestGetCrumbIgnoresErrorResponse(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/test/getcrumb" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if fail.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("Too Many Requests"))
+			return
+		}
+		w.Write([]byte(testCrumb))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv)
+
+	c.getCrumb(context.Background())
+	if _, crumb := c.session(); crumb != "" {
+		t.Fatalf("Expected no crumb after a 429, got %q", crumb)
+	}
+	fail.Store(false)
+	c.getCrumb(context.Background())
+	if _, crumb := c.session(); crumb != testCrumb {
+		t.Errorf("Expected crumb %q on the next bootstrap, got %q", testCrumb, crumb)
+	}
+}
+
+func TestNewTickerWithClient(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v10/finance/quoteSummary/AAPL" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL","shortName":"Apple Inc.","currency":"USD"}}],"error":null}}`))
+	})
+
+	ticker := NewTickerWithClient("AAPL", newTestClient(srv))
+	info, err := ticker.Info()
+	if err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+	if info.ShortName != "Apple Inc." {
+		t.Errorf("Expected ShortName 'Apple Inc.', got '%s'", info.ShortName)
+	}
+}
+
+func TestGetContextCancelledSkipsBootstrap(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := newTestClient(srv)
+	_, err := c.GetContext(ctx, srv.URL+"/v8/finance/chart/AAPL", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("Expected no requests to reach the server, got %d", n)
+	}
+}
+
+func TestHistoryContextDeadline(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	ticker := NewTickerWithClient("AAPL", newTestClient(srv))
+	_, err := ticker.HistoryContext(ctx, HistoryQuery{Range: "1mo", Interval: "1d"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClientConcurrentBootstrap(t *testing.T) {
+	var crumbRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cookie", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "A3", Value: "session"})
+	})
+	mux.HandleFunc("/v1/test/getcrumb", func(w http.ResponseWriter, r *http.Request) {
+		crumbRequests.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(testCrumb))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("crumb") != testCrumb {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	params := url.Values{"symbol": []string{"AAPL"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get(srv.URL+"/v8/finance/chart/AAPL", params)
+			if err != nil {
+				t.Errorf("Get returned error: %v", err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := crumbRequests.Load(); n != 1 {
+		t.Errorf("Expected a single crumb bootstrap, got %d", n)
+	}
+	if _, ok := params["crumb"]; ok {
+		t.Error("Get modified the caller's params")
+	}
+}
@@ -1,6 +1,7 @@
 package yahoofinanceapi
 
 var BASE_URL = "https://query2.finance.yahoo.com"
+var COOKIE_URL = "https://fc.yahoo.com"
 var USER_AGENTS = []string{
 	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36",
 	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36",
@@ -0,0 +1,423 @@
+package yahoofinanceapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CSVOptions configures the CSV encoding of series and option chains.
+type CSVOptions struct {
+	// Location is the timezone times are written in, and that times without an offset are read in.
+	// Writing defaults to the Location of the series, reading to UTC.
+	Location *time.Location
+	// NoHeader omits the header row. Without it, ReadSeriesCSV and ReadOptionCSV expect the columns
+	// in the order they are written in.
+	NoHeader bool
+	// Precision is the number of digits written after the decimal point of prices and other
+	// decimal values, or ShortestPrecision to write as few as needed to read back the exact value.
+	Precision int
+}
+
+// ShortestPrecision is the CSVOptions.Precision that writes numbers with as few digits as needed
+// to read back the exact value.
+const ShortestPrecision = -1
+
+// csvColumn is a CSV column of rows of type T.
+type csvColumn[T any] struct {
+	name  string
+	write func(row *T, opts CSVOptions) string
+	read  func(row *T, value string, opts CSVOptions) error
+}
+
+// seriesColumns are the columns of a Series in CSV. Times of daily or longer bars are written
+// as dates, others in RFC 3339.
+func seriesColumns(daily bool) []csvColumn[Bar] {
+	price := func(name string, field func(*Bar) *float64) csvColumn[Bar] {
+		return csvColumn[Bar]{
+			name:  name,
+			write: func(b *Bar, opts CSVOptions) string { return formatFloat(*field(b), opts.Precision) },
+			read: func(b *Bar, value string, _ CSVOptions) error {
+				v, err := parseFloat(value, math.NaN())
+				*field(b) = v
+				return err
+			},
+		}
+	}
+	event := func(name string, field func(*Bar) *float64) csvColumn[Bar] {
+		return csvColumn[Bar]{
+			name: name,
+			write: func(b *Bar, opts CSVOptions) string {
+				if *field(b) == 0 {
+					return ""
+				}
+				return formatFloat(*field(b), opts.Precision)
+			},
+			read: func(b *Bar, value string, _ CSVOptions) error {
+				v, err := parseFloat(value, 0)
+				*field(b) = v
+				return err
+			},
+		}
+	}
+	layout := time.RFC3339
+	if daily {
+		layout = "2006-01-02"
+	}
+	return []csvColumn[Bar]{
+		{
+			name:  "time",
+			write: func(b *Bar, opts CSVOptions) string { return barTime(b.Time, opts.Location, daily).Format(layout) },
+			read: func(b *Bar, value string, opts CSVOptions) error {
+				t, err := parseTime(value, opts.Location)
+				b.Time = t
+				return err
+			},
+		},
+		price("open", func(b *Bar) *float64 { return &b.Open }),
+		price("high", func(b *Bar) *float64 { return &b.High }),
+		price("low", func(b *Bar) *float64 { return &b.Low }),
+		price("close", func(b *Bar) *float64 { return &b.Close }),
+		price("adjclose", func(b *Bar) *float64 { return &b.AdjClose }),
+		{
+			name:  "volume",
+			write: func(b *Bar, _ CSVOptions) string { return strconv.FormatInt(b.Volume, 10) },
+			read: func(b *Bar, value string, _ CSVOptions) error {
+				v, err := parseInt(value)
+				b.Volume = v
+				return err
+			},
+		},
+		event("dividend", func(b *Bar) *float64 { return &b.Dividend }),
+		event("split", func(b *Bar) *float64 { return &b.SplitFactor }),
+		event("capitalgain", func(b *Bar) *float64 { return &b.CapitalGain }),
+		{
+			name:  "session",
+			write: func(b *Bar, _ CSVOptions) string { return string(b.Session) },
+			read: func(b *Bar, value string, _ CSVOptions) error {
+				b.Session = Session(value)
+				return nil
+			},
+		},
+	}
+}
+
+// WriteCSV writes the bars of s to w as CSV, one row per bar with the columns time, open, high,
+// low, close, adjclose, volume, dividend, split, capitalgain and session. Missing prices and
+// absent events are empty.
+func (s Series) WriteCSV(w io.Writer, opts CSVOptions) error {
+	if opts.Location == nil {
+		opts.Location = s.Location
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return writeCSV(w, seriesColumns(isDailyOrLonger(s.Interval)), s.Bars, opts)
+}
+
+// ReadSeriesCSV reads a Series written by Series.WriteCSV. Columns it does not know are ignored,
+// and missing ones read as empty. The corporate actions are rebuilt from the bars and dated at their
+// bar. The interval is not part of the CSV: it is guessed from the shortest time between two bars,
+// with dates read as daily, weekly or monthly bars.
+func ReadSeriesCSV(r io.Reader, opts CSVOptions) (Series, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	bars, err := readCSV(r, seriesColumns(false), opts)
+	if err != nil {
+		return Series{}, err
+	}
+	sort.SliceStable(bars, func(i, j int) bool { return bars[i].Time.Before(bars[j].Time) })
+
+	s := Series{Interval: guessInterval(bars), Location: opts.Location, Bars: bars}
+	for _, bar := range bars {
+		if bar.Dividend != 0 {
+			s.Dividends = append(s.Dividends, Dividend{Date: bar.Time, Amount: bar.Dividend})
+		}
+		if bar.SplitFactor != 0 {
+			split := Split{Date: bar.Time, Numerator: bar.SplitFactor, Denominator: 1}
+			if bar.SplitFactor < 1 {
+				split.Numerator, split.Denominator = 1, 1/bar.SplitFactor
+			}
+			split.Ratio = fmt.Sprintf("%g:%g", split.Numerator, split.Denominator)
+			s.Splits = append(s.Splits, split)
+		}
+		if bar.CapitalGain != 0 {
+			s.CapitalGains = append(s.CapitalGains, CapitalGain{Date: bar.Time, Amount: bar.CapitalGain})
+		}
+	}
+	return s, nil
+}
+
+// guessInterval returns the interval of bars from the shortest time between two of them.
+func guessInterval(bars []Bar) Interval {
+	var shortest time.Duration // 0 with fewer than two bars
+	for i := 1; i < len(bars); i++ {
+		if d := bars[i].Time.Sub(bars[i-1].Time); d > 0 && (shortest == 0 || d < shortest) {
+			shortest = d
+		}
+	}
+	daily := true
+	for _, bar := range bars {
+		if !bar.Time.Equal(midnight(bar.Time)) {
+			daily = false
+			break
+		}
+	}
+	switch {
+	case daily && shortest >= 28*day:
+		return Interval1mo
+	case daily && shortest >= 7*day:
+		return Interval1wk
+	case daily:
+		return Interval1d
+	}
+	for _, interval := range Intervals {
+		if intradayDurations[interval] == shortest {
+			return interval
+		}
+	}
+	return ""
+}
+
+// optionRow is an option contract in CSV, with whether it is a call or a put.
+type optionRow struct {
+	kind string
+	OptionDetail
+}
+
+// optionColumns are the columns of an option chain in CSV, named after the JSON fields of OptionDetail.
+func optionColumns() []csvColumn[optionRow] {
+	str := func(name string, field func(*optionRow) *string) csvColumn[optionRow] {
+		return csvColumn[optionRow]{
+			name:  name,
+			write: func(o *optionRow, _ CSVOptions) string { return *field(o) },
+			read: func(o *optionRow, value string, _ CSVOptions) error {
+				*field(o) = value
+				return nil
+			},
+		}
+	}
+	float := func(name string, field func(*optionRow) *float64) csvColumn[optionRow] {
+		return csvColumn[optionRow]{
+			name:  name,
+			write: func(o *optionRow, opts CSVOptions) string { return formatFloat(*field(o), opts.Precision) },
+			read: func(o *optionRow, value string, _ CSVOptions) error {
+				v, err := parseFloat(value, 0)
+				*field(o) = v
+				return err
+			},
+		}
+	}
+	integer := func(name string, field func(*optionRow) *int64) csvColumn[optionRow] {
+		return csvColumn[optionRow]{
+			name:  name,
+			write: func(o *optionRow, _ CSVOptions) string { return strconv.FormatInt(*field(o), 10) },
+			read: func(o *optionRow, value string, _ CSVOptions) error {
+				v, err := parseInt(value)
+				*field(o) = v
+				return err
+			},
+		}
+	}
+	return []csvColumn[optionRow]{
+		str("type", func(o *optionRow) *string { return &o.kind }),
+		str("contractSymbol", func(o *optionRow) *string { return &o.ContractSymbol }),
+		float("strike", func(o *optionRow) *float64 { return &o.Strike }),
+		str("currency", func(o *optionRow) *string { return &o.Currency }),
+		float("lastPrice", func(o *optionRow) *float64 { return &o.LastPrice }),
+		float("change", func(o *optionRow) *float64 { return &o.Change }),
+		float("percentChange", func(o *optionRow) *float64 { return &o.PercentChange }),
+		integer("volume", func(o *optionRow) *int64 { return &o.Volume }),
+		integer("openInterest", func(o *optionRow) *int64 { return &o.OpenInterest }),
+		float("bid", func(o *optionRow) *float64 { return &o.Bid }),
+		float("ask", func(o *optionRow) *float64 { return &o.Ask }),
+		str("contractSize", func(o *optionRow) *string { return &o.ContractSize }),
+		str("expiration", func(o *optionRow) *string { return &o.Expiration }),
+		str("lastTradeDate", func(o *optionRow) *string { return &o.LastTradeDate }),
+		float("impliedVolatility", func(o *optionRow) *float64 { return &o.ImpliedVolatility }),
+		{
+			name:  "inTheMoney",
+			write: func(o *optionRow, _ CSVOptions) string { return strconv.FormatBool(o.InTheMoney) },
+			read: func(o *optionRow, value string, _ CSVOptions) (err error) {
+				if value != "" {
+					o.InTheMoney, err = strconv.ParseBool(value)
+				}
+				return err
+			},
+		},
+	}
+}
+
+// WriteCSV writes the contracts of the option chain to w as CSV, the calls followed by the puts.
+// The first column, type, is "call" or "put"; the others are the fields of OptionDetail, named
+// after their JSON fields. Dates are written as they are, so opts.Location has no effect.
+func (o OptionData) WriteCSV(w io.Writer, opts CSVOptions) error {
+	rows := make([]optionRow, 0, len(o.Calls)+len(o.Puts))
+	for _, call := range o.Calls {
+		rows = append(rows, optionRow{kind: "call", OptionDetail: call})
+	}
+	for _, put := range o.Puts {
+		rows = append(rows, optionRow{kind: "put", OptionDetail: put})
+	}
+	return writeCSV(w, optionColumns(), rows, opts)
+}
+
+// ReadOptionCSV reads an option chain written by OptionData.WriteCSV. The expiration date of the
+// chain is that of its first contract; HasMiniOptions is not part of the CSV and is always false.
+func ReadOptionCSV(r io.Reader, opts CSVOptions) (OptionData, error) {
+	rows, err := readCSV(r, optionColumns(), opts)
+	if err != nil {
+		return OptionData{}, err
+	}
+	var data OptionData
+	for i, row := range rows {
+		switch row.kind {
+		case "call":
+			data.Calls = append(data.Calls, row.OptionDetail)
+		case "put":
+			data.Puts = append(data.Puts, row.OptionDetail)
+		default:
+			return OptionData{}, fmt.Errorf("CSV row %d: invalid option type %q", i+1, row.kind)
+		}
+		if data.ExpirationDate == "" {
+			data.ExpirationDate = row.Expiration
+		}
+	}
+	return data, nil
+}
+
+// writeCSV writes rows to w, one record per row with the given columns.
+func writeCSV[T any](w io.Writer, columns []csvColumn[T], rows []T, opts CSVOptions) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	if !opts.NoHeader {
+		for i, column := range columns {
+			record[i] = column.name
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	for i := range rows {
+		for j, column := range columns {
+			record[j] = column.write(&rows[i], opts)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV reads the rows of r. With a header, the columns are matched by name; without, by position.
+func readCSV[T any](r io.Reader, columns []csvColumn[T], opts CSVOptions) ([]T, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	order := columns
+	var absent []csvColumn[T] // Known columns missing from the header
+	if !opts.NoHeader {
+		header, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		byName := make(map[string]csvColumn[T], len(columns))
+		for _, column := range columns {
+			byName[column.name] = column
+		}
+		order = make([]csvColumn[T], len(header))
+		for i, name := range header {
+			order[i] = byName[name]
+			delete(byName, name)
+		}
+		for _, column := range columns {
+			if _, missing := byName[column.name]; missing {
+				absent = append(absent, column)
+			}
+		}
+	}
+
+	var rows []T
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		var row T
+		read := func(column csvColumn[T], value string) error {
+			if column.read == nil {
+				return nil
+			}
+			if err := column.read(&row, value, opts); err != nil {
+				line, _ := cr.FieldPos(0)
+				return fmt.Errorf("CSV line %d, column %s: %w", line, column.name, err)
+			}
+			return nil
+		}
+		// Columns missing from the record or the header are read as empty; extra fields are ignored.
+		for i, column := range order {
+			var value string
+			if i < len(record) {
+				value = record[i]
+			}
+			if err := read(column, value); err != nil {
+				return nil, err
+			}
+		}
+		for _, column := range absent {
+			if err := read(column, ""); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// formatFloat formats v with precision digits after the decimal point, or as few as needed with
+// ShortestPrecision. NaN is formatted as an empty string.
+func formatFloat(v float64, precision int) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
+
+// parseFloat parses a number written by formatFloat, returning empty for an empty string.
+func parseFloat(s string, empty float64) (float64, error) {
+	if s == "" {
+		return empty, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseInt parses an integer, returning 0 for an empty string.
+func parseInt(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseTime parses an RFC 3339 time into loc, or a date as midnight in loc.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
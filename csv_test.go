@@ -0,0 +1,142 @@
+package yahoofinanceapi
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSeriesCSVRoundTrip(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	daily := dailySeries("AAPL", ny, map[int]float64{2: 185.64, 3: 184.25, 6: 181.91, 7: 0.1 + 0.2})
+	daily.Bars[1].Dividend = 0.24
+	daily.Bars[2].SplitFactor = 4
+	daily.Bars[3].Open, daily.Bars[3].High = math.NaN(), math.NaN()
+	daily.Dividends = []Dividend{{Date: daily.Bars[1].Time, Amount: 0.24}}
+	daily.Splits = []Split{{Date: daily.Bars[2].Time, Numerator: 4, Denominator: 1, Ratio: "4:1"}}
+
+	intraday := Series{Interval: Interval5m, Location: ny, Bars: testBars(time.Date(2025, 1, 6, 9, 25, 0, 0, ny), 5*time.Minute, 3, SessionRegular)}
+	intraday.Bars[0].Session = SessionPre
+
+	for _, s := range []Series{daily, intraday} {
+		var buf bytes.Buffer
+		if err := s.WriteCSV(&buf, CSVOptions{Precision: ShortestPrecision}); err != nil {
+			t.Fatalf("WriteCSV returned error: %v", err)
+		}
+		got, err := ReadSeriesCSV(&buf, CSVOptions{Location: ny})
+		if err != nil {
+			t.Fatalf("ReadSeriesCSV returned error: %v", err)
+		}
+		if got.Interval != s.Interval || got.Location != ny || len(got.Bars) != len(s.Bars) {
+			t.Fatalf("Expected %d %s bars in %v, got %d %s bars in %v", len(s.Bars), s.Interval, ny, len(got.Bars), got.Interval, got.Location)
+		}
+		for i := range s.Bars {
+			want, bar := s.Bars[i], got.Bars[i]
+			if !bar.Time.Equal(want.Time) || bar.Time.Location() != ny {
+				t.Errorf("Bar %d: expected time %v, got %v", i, want.Time, bar.Time)
+			}
+			if math.IsNaN(want.Open) != math.IsNaN(bar.Open) {
+				t.Errorf("Bar %d: expected open %v, got %v", i, want.Open, bar.Open)
+			}
+			want.Time, bar.Time = time.Time{}, time.Time{}
+			want.Open, want.High, bar.Open, bar.High = 0, 0, 0, 0
+			if want != bar {
+				t.Errorf("Bar %d: expected %+v, got %+v", i, want, bar)
+			}
+		}
+		if !reflect.DeepEqual(got.Dividends, s.Dividends) || !reflect.DeepEqual(got.Splits, s.Splits) {
+			t.Errorf("Expected events %v %v, got %v %v", s.Dividends, s.Splits, got.Dividends, got.Splits)
+		}
+	}
+}
+
+func TestSeriesWriteCSVOptions(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	s := Series{Interval: Interval1h, Location: ny, Bars: testBars(time.Date(2025, 1, 6, 9, 30, 0, 0, ny), time.Hour, 1, SessionRegular)}
+	s.Bars[0].Close = 1.23456
+
+	var b strings.Builder
+	if err := s.WriteCSV(&b, CSVOptions{Location: time.UTC, NoHeader: true, Precision: 2}); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	want := "2025-01-06T14:30:00Z,1.00,2.00,0.00,1.23,1.50,100,,,,regular\n"
+	if b.String() != want {
+		t.Errorf("Expected %q, got %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := s.WriteCSV(&b, CSVOptions{NoHeader: true}); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	if want := "2025-01-06T09:30:00-05:00,1,2,0,1,2,100,,,,regular\n"; b.String() != want {
+		t.Errorf("Expected prices without decimals, %q, got %q", want, b.String())
+	}
+
+	got, err := ReadSeriesCSV(strings.NewReader(want), CSVOptions{NoHeader: true})
+	if err != nil {
+		t.Fatalf("ReadSeriesCSV returned error: %v", err)
+	}
+	if got.Len() != 1 || got.Bars[0].Close != 1.23 || got.Bars[0].Time.Location() != time.UTC {
+		t.Errorf("Unexpected bars %+v", got.Bars)
+	}
+}
+
+func TestReadSeriesCSVColumns(t *testing.T) {
+	in := "close,time,note\n2,2025-01-03,x\n1,2025-01-02,y\n"
+	s, err := ReadSeriesCSV(strings.NewReader(in), CSVOptions{})
+	if err != nil {
+		t.Fatalf("ReadSeriesCSV returned error: %v", err)
+	}
+	if s.Len() != 2 || s.Interval != Interval1d || s.Bars[0].Close != 1 || !math.IsNaN(s.Bars[0].Open) {
+		t.Errorf("Expected 2 daily bars sorted by time with only closes, got %+v", s)
+	}
+
+	// Fields beyond the header never fill the columns it lacks.
+	s, err = ReadSeriesCSV(strings.NewReader("time,close\n2024-01-02,1,99\n"), CSVOptions{})
+	if err != nil {
+		t.Fatalf("ReadSeriesCSV returned error: %v", err)
+	}
+	if bar := s.Bars[0]; bar.Close != 1 || !math.IsNaN(bar.Open) || bar.Volume != 0 {
+		t.Errorf("Expected only a close of 1, got %+v", bar)
+	}
+
+	if _, err := ReadSeriesCSV(strings.NewReader("time,close\n2025-01-02,abc\n"), CSVOptions{}); err == nil || !strings.Contains(err.Error(), "line 2, column close") {
+		t.Errorf("Expected an error for the invalid close, got %v", err)
+	}
+}
+
+func TestOptionCSVRoundTrip(t *testing.T) {
+	detail := OptionDetail{
+		ContractSymbol: "AAPL250117C00150000", Strike: 150, Currency: "USD", LastPrice: 80.5, Change: -1.25,
+		PercentChange: -1.5290519, Volume: 12, OpenInterest: 3400, Bid: 80.1, Ask: 81, ContractSize: "REGULAR",
+		Expiration: "2025-01-17", LastTradeDate: "2025-01-03", ImpliedVolatility: 0.4521, InTheMoney: true,
+	}
+	put := detail
+	put.ContractSymbol, put.InTheMoney = "AAPL250117P00150000", false
+	data := OptionData{ExpirationDate: "2025-01-17", Calls: []OptionDetail{detail, detail}, Puts: []OptionDetail{put}}
+
+	var buf bytes.Buffer
+	if err := data.WriteCSV(&buf, CSVOptions{Precision: ShortestPrecision}); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	want := "type,contractSymbol,strike,currency,lastPrice,change,percentChange,volume,openInterest,bid,ask,contractSize,expiration,lastTradeDate,impliedVolatility,inTheMoney"
+	if header != want {
+		t.Errorf("Expected header %s, got %s", want, header)
+	}
+
+	got, err := ReadOptionCSV(&buf, CSVOptions{})
+	if err != nil {
+		t.Fatalf("ReadOptionCSV returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("Expected %+v, got %+v", data, got)
+	}
+
+	if _, err := ReadOptionCSV(strings.NewReader(want+"\nstraddle\n"), CSVOptions{}); err == nil || !strings.Contains(err.Error(), "invalid option type") {
+		t.Errorf("Expected an error for the invalid option type, got %v", err)
+	}
+}
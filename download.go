@@ -0,0 +1,107 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultDownloadConcurrency is the number of symbols Download fetches at a time unless
+// DownloadOptions sets a Concurrency.
+const DefaultDownloadConcurrency = 8
+
+// DownloadOptions configures Download.
+type DownloadOptions struct {
+	Client      *Client // The client to send the requests with; defaults to the shared client of NewTicker
+	Concurrency int     // The number of symbols fetched at a time; defaults to DefaultDownloadConcurrency
+	// OnProgress, if set, is called after each symbol is fetched or fails. Calls never overlap.
+	OnProgress func(DownloadProgress)
+}
+
+// DownloadProgress reports the completion of one symbol of a Download.
+type DownloadProgress struct {
+	Symbol string
+	Err    error // The error fetching Symbol, or nil
+	Done   int   // The number of symbols completed so far, including Symbol
+	Total  int
+}
+
+// DownloadResult holds the histories Download fetched and the errors of the symbols it could not fetch.
+// Every symbol is in exactly one of the maps.
+type DownloadResult struct {
+	Series map[string]Series
+	Errors map[string]error
+}
+
+// Download fetches the history of query for each of symbols in parallel, up to opts.Concurrency at a time.
+// A symbol that fails does not stop the others; its error is recorded in the Errors of the result.
+// Duplicate symbols are fetched once.
+//
+// The returned error is only set when ctx is cancelled or its deadline passes during the download.
+// The result then holds the symbols fetched so far, and the others fail with ctx.Err().
+func Download(ctx context.Context, symbols []string, query HistoryQuery, opts DownloadOptions) (DownloadResult, error) {
+	client := opts.Client
+	if client == nil {
+		client = getClient()
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultDownloadConcurrency
+	}
+
+	var unique []string
+	seen := make(map[string]bool)
+	for _, symbol := range symbols {
+		if !seen[symbol] {
+			seen[symbol] = true
+			unique = append(unique, symbol)
+		}
+	}
+
+	result := DownloadResult{Series: make(map[string]Series), Errors: make(map[string]error)}
+	var mu sync.Mutex // guards result and the progress callback
+	complete := func(symbol string, series Series, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Errors[symbol] = err
+		} else {
+			result.Series[symbol] = series
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(DownloadProgress{Symbol: symbol, Err: err, Done: len(result.Series) + len(result.Errors), Total: len(unique)})
+		}
+	}
+
+	symbolsCh := make(chan string)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(unique)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range symbolsCh {
+				series, err := NewTickerWithClient(symbol, client).HistorySeriesContext(ctx, query)
+				complete(symbol, series, err)
+			}
+		}()
+	}
+	sent := 0
+dispatch:
+	for _, symbol := range unique {
+		select {
+		case symbolsCh <- symbol:
+			sent++
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(symbolsCh)
+	wg.Wait()
+	for _, symbol := range unique[sent:] {
+		complete(symbol, Series{}, ctx.Err())
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	client.logger.Debug("Downloaded histories", "symbols", len(unique), "errors", len(result.Errors))
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// downloadHandler serves one daily bar for every symbol except those starting with "BAD", which are not found.
+// It records the largest number of requests it served at once.
+func downloadHandler(inFlight, maxInFlight *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		symbol := strings.TrimPrefix(r.URL.Path, "/v8/finance/chart/")
+		if strings.HasPrefix(symbol, "BAD") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"symbol":%q,"dataGranularity":"1d"},"timestamp":[1704205800],
+			"indicators":{"quote":[{"open":[1],"high":[2],"low":[0.5],"close":[1.5],"volume":[100]}]}}],"error":null}}`, symbol)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := newTestServer(t, downloadHandler(&inFlight, &maxInFlight))
+
+	var symbols []string
+	for i := range 20 {
+		symbols = append(symbols, fmt.Sprintf("SYM%d", i))
+	}
+	symbols = append(symbols, "BAD1", "SYM0", "BAD2")
+
+	var progress []DownloadProgress
+	result, err := Download(context.Background(), symbols, HistoryQuery{Range: Range5d}, DownloadOptions{
+		Client:      newTestClient(srv),
+		Concurrency: 4,
+		OnProgress:  func(p DownloadProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if len(result.Series) != 20 || len(result.Errors) != 2 {
+		t.Fatalf("Expected 20 series and 2 errors, got %d and %d", len(result.Series), len(result.Errors))
+	}
+	if s := result.Series["SYM7"]; s.Symbol != "SYM7" || s.Len() != 1 {
+		t.Errorf("Unexpected series for SYM7: %+v", s)
+	}
+	if !errors.Is(result.Errors["BAD1"], ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound for BAD1, got %v", result.Errors["BAD1"])
+	}
+	if peak := maxInFlight.Load(); peak > 4 || peak < 2 {
+		t.Errorf("Expected up to 4 requests at a time, got %d", peak)
+	}
+
+	if len(progress) != 22 {
+		t.Fatalf("Expected 22 progress reports, got %d", len(progress))
+	}
+	for i, p := range progress {
+		if p.Done != i+1 || p.Total != 22 {
+			t.Errorf("Progress %d: expected %d of 22, got %d of %d", i, i+1, p.Done, p.Total)
+		}
+	}
+}
+
+func TestDownloadCancelled(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := newTestServer(t, downloadHandler(&inFlight, &maxInFlight))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	symbols := []string{"A", "B", "C", "D", "E", "F"}
+	result, err := Download(ctx, symbols, HistoryQuery{}, DownloadOptions{
+		Client:      newTestClient(srv),
+		Concurrency: 1,
+		OnProgress: func(p DownloadProgress) {
+			if p.Symbol == "B" {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(result.Series)+len(result.Errors) != len(symbols) {
+		t.Errorf("Expected every symbol in the result, got %d series and %d errors", len(result.Series), len(result.Errors))
+	}
+	if _, ok := result.Series["A"]; !ok {
+		t.Error("Expected the series fetched before the cancellation")
+	}
+	if !errors.Is(result.Errors["F"], context.Canceled) {
+		t.Errorf("Expected F to fail with context.Canceled, got %v", result.Errors["F"])
+	}
+}
@@ -0,0 +1,137 @@
+package yahoofinanceapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the failure classes callers usually want to handle.
+// Use errors.Is to test for them; errors.As with *YahooAPIError gives access to the details.
+var (
+	ErrSymbolNotFound = errors.New("symbol not found")
+	ErrRateLimited    = errors.New("rate limited by Yahoo Finance")
+	ErrUnauthorized   = errors.New("unauthorized by Yahoo Finance")
+	ErrInvalidRange   = errors.New("invalid range or interval")
+	ErrNoOptions      = errors.New("no options available")
+	// ErrBeyondRetention is returned for intraday history starting before the oldest bars Yahoo
+	// keeps for the interval, e.g. 30 days for 1m bars. It matches ErrInvalidRange as well.
+	ErrBeyondRetention = fmt.Errorf("%w: beyond Yahoo Finance's retention", ErrInvalidRange)
+)
+
+// YahooError is the error object Yahoo Finance embeds in chart, quoteSummary and optionChain responses.
+type YahooError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// YahooAPIError is returned when Yahoo Finance rejects a request.
+// Code and Description come from the error payload when Yahoo sends one.
+type YahooAPIError struct {
+	Code        string
+	Description string
+	HTTPStatus  int
+}
+
+// newAPIError builds a YahooAPIError from an error payload and the HTTP status it arrived with.
+func newAPIError(payload *YahooError, httpStatus int) *YahooAPIError {
+	return &YahooAPIError{Code: payload.Code, Description: payload.Description, HTTPStatus: httpStatus}
+}
+
+func (e *YahooAPIError) Error() string {
+	msg := "yahoo finance"
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	if e.HTTPStatus != 0 {
+		msg += fmt.Sprintf(" (HTTP %d)", e.HTTPStatus)
+	}
+	return msg
+}
+
+// Is maps the error onto the package's sentinel errors, so that
+// errors.Is(err, ErrSymbolNotFound) works regardless of the endpoint.
+func (e *YahooAPIError) Is(target error) bool {
+	code := strings.ToLower(e.Code)
+	switch target {
+	case ErrRateLimited:
+		return e.HTTPStatus == http.StatusTooManyRequests || code == "too many requests"
+	case ErrUnauthorized:
+		return e.HTTPStatus == http.StatusUnauthorized || code == "unauthorized"
+	case ErrSymbolNotFound:
+		return e.HTTPStatus == http.StatusNotFound || code == "not found"
+	case ErrInvalidRange:
+		badRequest := e.HTTPStatus == http.StatusBadRequest || e.HTTPStatus == http.StatusUnprocessableEntity ||
+			code == "bad request" || code == "unprocessable entity"
+		if !badRequest {
+			return false
+		}
+		description := strings.ToLower(e.Description)
+		for _, word := range []string{"range", "interval", "period", "startdate", "starttime", "enddate", "endtime"} {
+			if strings.Contains(description, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxBodySnippet is how much of an unexpected response body is kept in error messages.
+const maxBodySnippet = 200
+
+// bodySnippet returns the start of body, trimmed for use in error messages.
+func bodySnippet(body []byte) string {
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) > maxBodySnippet {
+		snippet = strings.ToValidUTF8(snippet[:maxBodySnippet], "") + "..."
+	}
+	return snippet
+}
+
+// isSuccess reports whether statusCode is in the 2xx range.
+func isSuccess(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+// statusError describes a non-2xx response that carried no Yahoo error payload.
+func statusError(statusCode int, body []byte) *YahooAPIError {
+	return &YahooAPIError{Code: http.StatusText(statusCode), Description: bodySnippet(body), HTTPStatus: statusCode}
+}
+
+// checkStatus returns a *YahooAPIError if resp has a non-2xx status.
+// Call it after checking the decoded payload for a more specific Yahoo error.
+func checkStatus(resp *http.Response) error {
+	if isSuccess(resp.StatusCode) {
+		return nil
+	}
+	return statusError(resp.StatusCode, nil)
+}
+
+// decodeJSON reads the body of resp into v.
+// A non-2xx response that is not JSON (e.g. an HTML error page) becomes a *YahooAPIError;
+// any other decode failure reports the status and the start of the body for diagnostics.
+func decodeJSON(resp *http.Response, v any) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		if !isSuccess(resp.StatusCode) {
+			return statusError(resp.StatusCode, body)
+		}
+		// Responses built by a custom RoundTripper may carry no Request.
+		from := ""
+		if resp.Request != nil && resp.Request.URL != nil {
+			from = " from " + resp.Request.URL.Path
+		}
+		return fmt.Errorf("failed to decode JSON response%s (HTTP %d, body %q): %w",
+			from, resp.StatusCode, bodySnippet(body), err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package yahoofinanceapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestYahooAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *YahooAPIError
+		target error
+		want   bool
+	}{
+		{"not found code", &YahooAPIError{Code: "Not Found", Description: "No data found, symbol may be delisted", HTTPStatus: 404}, ErrSymbolNotFound, true},
+		{"rate limited status", &YahooAPIError{HTTPStatus: 429}, ErrRateLimited, true},
+		{"invalid crumb", &YahooAPIError{Code: "Unauthorized", Description: "Invalid Crumb", HTTPStatus: 401}, ErrUnauthorized, true},
+		{"invalid interval", &YahooAPIError{Code: "Bad Request", Description: "Invalid input - interval=2h is not supported", HTTPStatus: 400}, ErrInvalidRange, true},
+		{"other bad request", &YahooAPIError{Code: "Bad Request", Description: "Invalid symbol format", HTTPStatus: 400}, ErrInvalidRange, false},
+		{"not found is not rate limited", &YahooAPIError{Code: "Not Found", HTTPStatus: 404}, ErrRateLimited, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYahooAPIErrorMessage(t *testing.T) {
+	err := &YahooAPIError{Code: "Not Found", Description: "Quote not found for symbol: XYZ", HTTPStatus: 404}
+	want := "yahoo finance: Not Found: Quote not found for symbol: XYZ (HTTP 404)"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestEndpointsReturnTypedErrors(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v8/finance/chart/MISSING":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`))
+		case "/v8/finance/chart/AAPL":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Unprocessable Entity","description":"1m data not available for startTime=1 and endTime=2. The requested range must be within the last 30 days."}}}`))
+		case "/v10/finance/quoteSummary/MISSING":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found for symbol: MISSING"}}}`))
+		case "/v1/finance/search":
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	c := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	_, err := NewTickerWithClient("MISSING", c).History(HistoryQuery{})
+	var apiErr *YahooAPIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusNotFound {
+		t.Errorf("Expected *YahooAPIError with HTTP 404 from chart, got %v", err)
+	}
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from chart, got %v", err)
+	}
+
+	_, err = NewTickerWithClient("AAPL", c).History(HistoryQuery{Interval: "1m", Range: "5d"})
+	if !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange from chart, got %v", err)
+	}
+
+	_, err = NewTickerWithClient("MISSING", c).Info()
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from quoteSummary, got %v", err)
+	}
+
+	_, err = NewTickerWithClient("AAPL", c).Search("AAPL", 5)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited from search, got %v", err)
+	}
+}
+
+func TestEmptyChartResultIsSymbolNotFound(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"chart":{"result":[],"error":null}}`))
+	})
+
+	_, err := newHistoryWithClient(newTestClient(srv)).GetHistory("EMPTY")
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound, got %v", err)
+	}
+}
+
+func TestHTMLResponsesReturnErrors(t *testing.T) {
+	status := http.StatusOK
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(status)
+		w.Write([]byte("<html><body>Will be right back...</body></html>"))
+	})
+	c := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	ticker := NewTickerWithClient("AAPL", c)
+
+	calls := map[string]func() error{
+		"chart":        func() error { _, err := ticker.History(HistoryQuery{}); return err },
+		"options":      func() error { _, err := ticker.OptionChain(); return err },
+		"quoteSummary": func() error { _, err := ticker.Info(); return err },
+	}
+
+	for name, call := range calls {
+		status = http.StatusOK
+		err := call()
+		if err == nil {
+			t.Fatalf("%s: expected decode error for HTML body, got nil", name)
+		}
+		if !strings.Contains(err.Error(), "HTTP 200") || !strings.Contains(err.Error(), "Will be right back") {
+			t.Errorf("%s: expected status and body snippet in %q", name, err)
+		}
+
+		status = http.StatusServiceUnavailable
+		var apiErr *YahooAPIError
+		if err := call(); !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusServiceUnavailable {
+			t.Errorf("%s: expected *YahooAPIError with HTTP 503, got %v", name, err)
+		}
+	}
+}
+
+func TestNon2xxJSONWithoutPayloadError(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"quoteSummary":{"result":null,"error":null}}`))
+	})
+	c := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	if _, err := NewTickerWithClient("AAPL", c).Info(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestBodySnippetTruncates(t *testing.T) {
+	snippet := bodySnippet([]byte(strings.Repeat("x", 500)))
+	if len(snippet) != maxBodySnippet+len("...") {
+		t.Errorf("Expected snippet of %d bytes, got %d", maxBodySnippet+3, len(snippet))
+	}
+}
+
+func TestDecodeErrorWithoutRequest(t *testing.T) {
+	// A custom transport may build responses without setting their Request.
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("not json"))}, nil
+	})
+	client := NewClient(WithTransport(transport), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	_, err := NewTickerWithClient("AAPL", client).Info()
+	if err == nil || !strings.Contains(err.Error(), "failed to decode JSON response (HTTP 200") {
+		t.Errorf("Expected a decode error, got %v", err)
+	}
+}
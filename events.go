@@ -0,0 +1,148 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Dividend is a cash dividend paid per share, dated on its ex-dividend date.
+type Dividend struct {
+	Date   time.Time
+	Amount float64
+}
+
+// Split is a stock split dated on its ex-date. A 4:1 split has Numerator 4 and Denominator 1.
+type Split struct {
+	Date        time.Time
+	Numerator   float64
+	Denominator float64
+	Ratio       string // As reported by Yahoo, e.g. "4:1"
+}
+
+// Factor returns the number of new shares per old share, e.g. 4 for a 4:1 split.
+func (s Split) Factor() float64 {
+	if s.Denominator == 0 {
+		return 0
+	}
+	return s.Numerator / s.Denominator
+}
+
+// CapitalGain is a capital gain distribution per share, as paid by funds, dated on its ex-date.
+type CapitalGain struct {
+	Date   time.Time
+	Amount float64
+}
+
+// dividends returns the dividends of the events in chronological order, dated in loc.
+func (e *YahooEvents) dividends(loc *time.Location) []Dividend {
+	if e == nil {
+		return nil
+	}
+	dividends := make([]Dividend, 0, len(e.Dividends))
+	for _, d := range e.Dividends {
+		dividends = append(dividends, Dividend{Date: time.Unix(d.Date, 0).In(loc), Amount: d.Amount})
+	}
+	sort.Slice(dividends, func(i, j int) bool { return dividends[i].Date.Before(dividends[j].Date) })
+	return dividends
+}
+
+// splits returns the splits of the events in chronological order, dated in loc.
+func (e *YahooEvents) splits(loc *time.Location) []Split {
+	if e == nil {
+		return nil
+	}
+	splits := make([]Split, 0, len(e.Splits))
+	for _, s := range e.Splits {
+		splits = append(splits, Split{Date: time.Unix(s.Date, 0).In(loc), Numerator: s.Numerator, Denominator: s.Denominator, Ratio: s.SplitRatio})
+	}
+	sort.Slice(splits, func(i, j int) bool { return splits[i].Date.Before(splits[j].Date) })
+	return splits
+}
+
+// capitalGains returns the capital gain distributions of the events in chronological order, dated in loc.
+func (e *YahooEvents) capitalGains(loc *time.Location) []CapitalGain {
+	if e == nil {
+		return nil
+	}
+	gains := make([]CapitalGain, 0, len(e.CapitalGains))
+	for _, g := range e.CapitalGains {
+		gains = append(gains, CapitalGain{Date: time.Unix(g.Date, 0).In(loc), Amount: g.Amount})
+	}
+	sort.Slice(gains, func(i, j int) bool { return gains[i].Date.Before(gains[j].Date) })
+	return gains
+}
+
+// barIndex returns the index of the bar whose interval contains t, i.e. the last bar starting at or
+// before t, or -1 if t is before the first bar.
+func (s Series) barIndex(t time.Time) int {
+	return sort.Search(len(s.Bars), func(i int) bool { return s.Bars[i].Time.After(t) }) - 1
+}
+
+// attachEvents copies the events onto the bars they fall into.
+func (s *Series) attachEvents() {
+	for _, d := range s.Dividends {
+		if i := s.barIndex(d.Date); i >= 0 {
+			s.Bars[i].Dividend += d.Amount
+		}
+	}
+	for _, split := range s.Splits {
+		if i := s.barIndex(split.Date); i >= 0 {
+			if s.Bars[i].SplitFactor == 0 {
+				s.Bars[i].SplitFactor = 1
+			}
+			s.Bars[i].SplitFactor *= split.Factor()
+		}
+	}
+	for _, g := range s.CapitalGains {
+		if i := s.barIndex(g.Date); i >= 0 {
+			s.Bars[i].CapitalGain += g.Amount
+		}
+	}
+}
+
+// eventsQuery is the history requested for Ticker.Dividends, Ticker.Splits and Ticker.CapitalGains.
+var eventsQuery = HistoryQuery{Range: RangeMax, Interval: Interval1d}
+
+// Dividends returns every dividend of the Ticker's symbol in chronological order.
+func (t *Ticker) Dividends() ([]Dividend, error) {
+	return t.DividendsContext(context.Background())
+}
+
+// DividendsContext is like Dividends but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) DividendsContext(ctx context.Context) ([]Dividend, error) {
+	series, err := t.HistorySeriesContext(ctx, eventsQuery)
+	if err != nil {
+		return nil, err
+	}
+	return series.Dividends, nil
+}
+
+// Splits returns every stock split of the Ticker's symbol in chronological order.
+func (t *Ticker) Splits() ([]Split, error) {
+	return t.SplitsContext(context.Background())
+}
+
+// SplitsContext is like Splits but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) SplitsContext(ctx context.Context) ([]Split, error) {
+	series, err := t.HistorySeriesContext(ctx, eventsQuery)
+	if err != nil {
+		return nil, err
+	}
+	return series.Splits, nil
+}
+
+// CapitalGains returns every capital gain distribution of the Ticker's symbol in chronological order.
+// Only funds report capital gains; for other symbols the result is empty.
+func (t *Ticker) CapitalGains() ([]CapitalGain, error) {
+	return t.CapitalGainsContext(context.Background())
+}
+
+// CapitalGainsContext is like CapitalGains but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) CapitalGainsContext(ctx context.Context) ([]CapitalGain, error) {
+	series, err := t.HistorySeriesContext(ctx, eventsQuery)
+	if err != nil {
+		return nil, err
+	}
+	return series.CapitalGains, nil
+}
@@ -0,0 +1,104 @@
+package yahoofinanceapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testEventsChartJSON is a weekly chart whose events fall on days inside the bars.
+const testEventsChartJSON = `{"chart":{"result":[{
+	"meta":{"symbol":"AAPL","dataGranularity":"1wk"},
+	"timestamp":[1596427200,1597032000,1597636800,1598241600,1598846400],
+	"events":{
+		"dividends":{"1596805800":{"amount":0.82,"date":1596805800}},
+		"splits":{"1598880600":{"date":1598880600,"numerator":4,"denominator":1,"splitRatio":"4:1"}},
+		"capitalGains":{"1597411800":{"amount":0.25,"date":1597411800},"1597066200":{"amount":0.5,"date":1597066200}}
+	},
+	"indicators":{"quote":[{
+		"open":[432.8,450.4,463.8,503.0,127.6],
+		"high":[457.7,464.2,499.5,515.1,137.9],
+		"low":[431.6,437.3,459.1,495.0,120.8],
+		"close":[444.5,460.0,497.5,499.2,120.9],
+		"volume":[44480000,40020000,53110000,46310000,332600000]
+	}]}
+}],"error":null}}`
+
+func TestSeriesEvents(t *testing.T) {
+	var gotEvents string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotEvents = r.URL.Query().Get("events")
+		w.Write([]byte(testEventsChartJSON))
+	})
+
+	series, err := NewTickerWithClient("AAPL", newTestClient(srv)).HistorySeries(HistoryQuery{Range: "1mo", Interval: "1wk"})
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if gotEvents != "div,splits,capitalGains" {
+		t.Errorf("Expected events to be requested, got %q", gotEvents)
+	}
+
+	if len(series.Dividends) != 1 || series.Dividends[0].Amount != 0.82 || !series.Dividends[0].Date.Equal(time.Unix(1596805800, 0)) {
+		t.Errorf("Unexpected dividends %+v", series.Dividends)
+	}
+	if len(series.Splits) != 1 || series.Splits[0].Factor() != 4 || series.Splits[0].Ratio != "4:1" {
+		t.Errorf("Unexpected splits %+v", series.Splits)
+	}
+	if len(series.CapitalGains) != 2 || series.CapitalGains[0].Amount != 0.5 {
+		t.Errorf("Expected capital gains in chronological order, got %+v", series.CapitalGains)
+	}
+
+	// Events land on the weekly bar their ex-date falls into.
+	wantDividend := []float64{0.82, 0, 0, 0, 0}
+	wantSplit := []float64{0, 0, 0, 0, 4}
+	wantGain := []float64{0, 0.75, 0, 0, 0}
+	for i, bar := range series.Bars {
+		if bar.Dividend != wantDividend[i] || bar.SplitFactor != wantSplit[i] || bar.CapitalGain != wantGain[i] {
+			t.Errorf("Bar %d: expected dividend %v, split %v, gain %v, got %v, %v, %v",
+				i, wantDividend[i], wantSplit[i], wantGain[i], bar.Dividend, bar.SplitFactor, bar.CapitalGain)
+		}
+	}
+
+	firstWeeks := series.Between(time.Time{}, time.Unix(1597636800, 0))
+	if len(firstWeeks.Dividends) != 1 || len(firstWeeks.Splits) != 0 || len(firstWeeks.CapitalGains) != 2 {
+		t.Errorf("Expected Between to filter events, got %+v", firstWeeks)
+	}
+}
+
+func TestTickerEvents(t *testing.T) {
+	var gotRange string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.URL.Query().Get("range")
+		w.Write([]byte(testEventsChartJSON))
+	})
+	ticker := NewTickerWithClient("AAPL", newTestClient(srv))
+
+	dividends, err := ticker.Dividends()
+	if err != nil || len(dividends) != 1 {
+		t.Errorf("Expected 1 dividend, got %v (err=%v)", dividends, err)
+	}
+	if gotRange != "max" {
+		t.Errorf("Expected the full history to be requested, got range %q", gotRange)
+	}
+	splits, err := ticker.Splits()
+	if err != nil || len(splits) != 1 {
+		t.Errorf("Expected 1 split, got %v (err=%v)", splits, err)
+	}
+	gains, err := ticker.CapitalGains()
+	if err != nil || len(gains) != 2 {
+		t.Errorf("Expected 2 capital gains, got %v (err=%v)", gains, err)
+	}
+}
+
+func TestSeriesWithoutEvents(t *testing.T) {
+	s := newSeries(testSeriesResult(), "")
+	if len(s.Dividends) != 0 || len(s.Splits) != 0 || len(s.CapitalGains) != 0 {
+		t.Errorf("Expected no events, got %+v", s)
+	}
+	for _, bar := range s.Bars {
+		if bar.Dividend != 0 || bar.SplitFactor != 0 || bar.CapitalGain != 0 {
+			t.Errorf("Expected no events on bar %+v", bar)
+		}
+	}
+}
@@ -1,13 +1,15 @@
 package yahoofinanceapi
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
-	"log/slog"
 	"math/rand"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,43 +19,69 @@ type YahooHistoryRespose struct {
 
 type YahooChart struct {
 	Result []YahooHistoryResult `json:"result"`
+	Error  *YahooError          `json:"error"`
 }
 
 type YahooHistoryResult struct {
 	Meta       YahooMeta      `json:"meta"`
 	Timestamp  []int64        `json:"timestamp"`
+	Events     *YahooEvents   `json:"events,omitempty"`
 	Indicators YahooIndicator `json:"indicators"`
 }
 
+// YahooEvents holds the corporate actions of a chart response, keyed by their date as a Unix timestamp string.
+type YahooEvents struct {
+	Dividends    map[string]YahooDividend    `json:"dividends,omitempty"`
+	Splits       map[string]YahooSplit       `json:"splits,omitempty"`
+	CapitalGains map[string]YahooCapitalGain `json:"capitalGains,omitempty"`
+}
+
+type YahooDividend struct {
+	Amount float64 `json:"amount"`
+	Date   int64   `json:"date"`
+}
+
+type YahooSplit struct {
+	Date        int64   `json:"date"`
+	Numerator   float64 `json:"numerator"`
+	Denominator float64 `json:"denominator"`
+	SplitRatio  string  `json:"splitRatio"`
+}
+
+type YahooCapitalGain struct {
+	Amount float64 `json:"amount"`
+	Date   int64   `json:"date"`
+}
+
 type YahooMeta struct {
-	Currency             string                 `json:"currency"`
-	Symbol               string                 `json:"symbol"`
-	ExchangeName         string                 `json:"exchangeName"`
-	FullExchangeName     string                 `json:"fullExchangeName"`
-	InstrumentType       string                 `json:"instrumentType"`
-	FirstTradeDate       int64                  `json:"firstTradeDate"`
-	RegularMarketTime    int64                  `json:"regularMarketTime"`
-	HasPrePostMarketData bool                   `json:"hasPrePostMarketData"`
-	GmtOffset            int                    `json:"gmtoffset"`
-	Timezone             string                 `json:"timezone"`
-	ExchangeTimezoneName string                 `json:"exchangeTimezoneName"`
-	RegularMarketPrice   float64                `json:"regularMarketPrice"`
-	FiftyTwoWeekHigh     float64                `json:"fiftyTwoWeekHigh"`
-	FiftyTwoWeekLow      float64                `json:"fiftyTwoWeekLow"`
-	RegularMarketDayHigh float64                `json:"regularMarketDayHigh"`
-	RegularMarketDayLow  float64                `json:"regularMarketDayLow"`
-	RegularMarketVolume  int64                  `json:"regularMarketVolume"`
-	LongName             string                 `json:"longName"`
-	ShortName            string                 `json:"shortName"`
-	ChartPreviousClose   float64                `json:"chartPreviousClose"`
-	PreviousClose        float64                `json:"previousClose"`
-	Scale                int                    `json:"scale"`
-	PriceHint            int                    `json:"priceHint"`
-	CurrentTradingPeriod YahooTradingPeriod     `json:"currentTradingPeriod"`
-	TradingPeriods       [][]YahooTradingPeriod `json:"tradingPeriods"`
-	DataGranularity      string                 `json:"dataGranularity"`
-	Range                string                 `json:"range"`
-	ValidRanges          []string               `json:"validRanges"`
+	Currency             string                    `json:"currency"`
+	Symbol               string                    `json:"symbol"`
+	ExchangeName         string                    `json:"exchangeName"`
+	FullExchangeName     string                    `json:"fullExchangeName"`
+	InstrumentType       string                    `json:"instrumentType"`
+	FirstTradeDate       int64                     `json:"firstTradeDate"`
+	RegularMarketTime    int64                     `json:"regularMarketTime"`
+	HasPrePostMarketData bool                      `json:"hasPrePostMarketData"`
+	GmtOffset            int                       `json:"gmtoffset"`
+	Timezone             string                    `json:"timezone"`
+	ExchangeTimezoneName string                    `json:"exchangeTimezoneName"`
+	RegularMarketPrice   float64                   `json:"regularMarketPrice"`
+	FiftyTwoWeekHigh     float64                   `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow      float64                   `json:"fiftyTwoWeekLow"`
+	RegularMarketDayHigh float64                   `json:"regularMarketDayHigh"`
+	RegularMarketDayLow  float64                   `json:"regularMarketDayLow"`
+	RegularMarketVolume  int64                     `json:"regularMarketVolume"`
+	LongName             string                    `json:"longName"`
+	ShortName            string                    `json:"shortName"`
+	ChartPreviousClose   float64                   `json:"chartPreviousClose"`
+	PreviousClose        float64                   `json:"previousClose"`
+	Scale                int                       `json:"scale"`
+	PriceHint            int                       `json:"priceHint"`
+	CurrentTradingPeriod YahooCurrentTradingPeriod `json:"currentTradingPeriod"`
+	TradingPeriods       YahooTradingPeriods       `json:"tradingPeriods"`
+	DataGranularity      string                    `json:"dataGranularity"`
+	Range                string                    `json:"range"`
+	ValidRanges          []string                  `json:"validRanges"`
 }
 
 type YahooTradingPeriod struct {
@@ -63,121 +91,272 @@ type YahooTradingPeriod struct {
 	GmtOffset int    `json:"gmtoffset"`
 }
 
+// YahooCurrentTradingPeriod holds the sessions of the exchange's current trading day.
+type YahooCurrentTradingPeriod struct {
+	Pre     YahooTradingPeriod `json:"pre"`
+	Regular YahooTradingPeriod `json:"regular"`
+	Post    YahooTradingPeriod `json:"post"`
+}
+
+// YahooTradingPeriods holds the sessions of each day of an intraday chart, one slice per day.
+// Yahoo reports them as an array of regular sessions, or as an object with pre, regular and post
+// sessions when pre- and post-market data is requested; both decode into YahooTradingPeriods.
+type YahooTradingPeriods struct {
+	Pre     [][]YahooTradingPeriod `json:"pre,omitempty"`
+	Regular [][]YahooTradingPeriod `json:"regular,omitempty"`
+	Post    [][]YahooTradingPeriod `json:"post,omitempty"`
+}
+
+// yahooTradingPeriods has the fields of YahooTradingPeriods without its JSON methods.
+type yahooTradingPeriods YahooTradingPeriods
+
+func (p *YahooTradingPeriods) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		*p = YahooTradingPeriods{}
+		return json.Unmarshal(data, &p.Regular)
+	}
+	return json.Unmarshal(data, (*yahooTradingPeriods)(p))
+}
+
+// MarshalJSON encodes p the way Yahoo does: null without periods, an array with only regular
+// sessions and an object otherwise.
+func (p YahooTradingPeriods) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.Pre == nil && p.Regular == nil && p.Post == nil:
+		return []byte("null"), nil
+	case p.Pre == nil && p.Post == nil:
+		return json.Marshal(p.Regular)
+	default:
+		return json.Marshal(yahooTradingPeriods(p))
+	}
+}
+
 type YahooIndicator struct {
-	Quote []YahooQuote `json:"quote"`
+	Quote    []YahooQuote    `json:"quote"`
+	AdjClose []YahooAdjClose `json:"adjclose,omitempty"`
+}
+
+// YahooAdjClose holds the close prices adjusted for splits and dividends. Yahoo omits it for intraday intervals.
+type YahooAdjClose struct {
+	AdjClose []*float64 `json:"adjclose"`
 }
 
+// YahooQuote holds the price arrays of a chart, parallel to its timestamps. Yahoo reports null
+// for intervals without trades, which decode as nil.
 type YahooQuote struct {
-	Open   []float64 `json:"open"`
-	High   []float64 `json:"high"`
-	Low    []float64 `json:"low"`
-	Close  []float64 `json:"close"`
-	Volume []int64   `json:"volume"`
+	Open   []*float64 `json:"open"`
+	High   []*float64 `json:"high"`
+	Low    []*float64 `json:"low"`
+	Close  []*float64 `json:"close"`
+	Volume []*int64   `json:"volume"`
 }
 
 type PriceData struct {
-	Open   float64
-	High   float64
-	Low    float64
-	Close  float64
-	Volume int64
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	AdjClose float64 // Close adjusted for splits and dividends; equal to Close when Yahoo reports none, e.g. intraday
+	Volume   int64
 }
 
+// HistoryQuery selects the price history to fetch: either a Range ending today, or the period from
+// Start to End. Empty fields get the defaults of SetDefault.
 type HistoryQuery struct {
-	Range     string
-	Interval  string
-	Start     string
-	End       string
+	Range     Range
+	Interval  Interval
+	Start     time.Time // Inclusive
+	End       time.Time // Exclusive; defaults to now when Start is set
 	UserAgent string
+
+	// AutoAdjust back-adjusts Open, High, Low and Close for splits and dividends, scaling each bar
+	// by AdjClose/Close like yfinance's auto_adjust. Close then equals AdjClose.
+	AutoAdjust bool
+	// AdjustVolume multiplies each bar's Volume by the factors of the splits after it, so volumes
+	// are in the same share units as the latest bars. It requires the split events of the history.
+	AdjustVolume bool
+	// IncludePrePost requests the pre- and post-market bars of intraday intervals. Each bar's
+	// Session tells which session it belongs to.
+	IncludePrePost bool
+	// UTC returns bar and event times in UTC instead of the exchange's timezone. Daily or longer
+	// bars keep their trading date and start at midnight UTC.
+	UTC bool
+	// Missing selects how bars with missing prices are handled; by default they are dropped.
+	// Series.Missing reports how many bars were affected.
+	Missing MissingPolicy
+	// Concurrency is the number of requests made in parallel when a Start/End window is longer
+	// than Yahoo serves in one request for the interval, e.g. more than 7 days of 1m bars, and is
+	// fetched in chunks. By default the chunks are fetched one after another.
+	Concurrency int
 }
 
+// SetDefault fills in the empty fields of the query: a Range of 1mo unless Start is set, an
+// Interval of 1d, End now for a Start/End query and a random user agent.
 func (hq *HistoryQuery) SetDefault() {
-	if hq.Range == "" && hq.Start == "" {
-		hq.Range = "1mo"
+	if hq.Range == "" && hq.Start.IsZero() {
+		hq.Range = Range1mo
 	}
 	if hq.Interval == "" {
-		hq.Interval = "1d"
-	}
-	if hq.Start != "" {
-		t, err := time.Parse("2006-01-02", hq.Start)
-		if err != nil {
-			log.Printf("Failed to parse start date: %v\n", err)
-			hq.Start = "default"
-		} else {
-			hq.Start = fmt.Sprintf("%d", t.Unix())
-		}
+		hq.Interval = Interval1d
 	}
-	if hq.End == "" {
-		hq.End = fmt.Sprintf("%d", time.Now().Unix())
+	if !hq.Start.IsZero() && hq.End.IsZero() {
+		hq.End = time.Now()
 	}
 	if hq.UserAgent == "" {
 		hq.UserAgent = USER_AGENTS[rand.Intn(len(USER_AGENTS))]
 	}
 }
 
+// Validate checks the query against the ranges and intervals Yahoo Finance serves and the
+// combinations it accepts. The error matches ErrInvalidRange. Empty fields are valid.
+func (hq HistoryQuery) Validate() error {
+	if hq.Interval != "" && !hq.Interval.Valid() {
+		return fmt.Errorf("%w: unknown interval %q", ErrInvalidRange, hq.Interval)
+	}
+	if hq.Range != "" && !hq.Range.Valid() {
+		return fmt.Errorf("%w: unknown range %q", ErrInvalidRange, hq.Range)
+	}
+	if hq.Start.IsZero() && !hq.End.IsZero() {
+		return fmt.Errorf("%w: end %s without a start", ErrInvalidRange, hq.End.Format(time.RFC3339))
+	}
+	if hq.Range != "" && !hq.Start.IsZero() {
+		return fmt.Errorf("%w: range %s cannot be combined with a start", ErrInvalidRange, hq.Range)
+	}
+	if !hq.End.IsZero() && !hq.End.After(hq.Start) {
+		return fmt.Errorf("%w: end %s is not after start %s", ErrInvalidRange, hq.End.Format(time.RFC3339), hq.Start.Format(time.RFC3339))
+	}
+	if limits, ok := intradayLimits[hq.Interval]; ok && hq.Range != "" {
+		if duration, ok := rangeDurations[hq.Range]; !ok || duration > limits.window {
+			return fmt.Errorf("%w: %s bars are only served for ranges of up to %d days, not %s",
+				ErrInvalidRange, hq.Interval, int(limits.window/day), hq.Range)
+		}
+	}
+	return nil
+}
+
+// History fetches price history. It is safe for concurrent use; each request works on
+// its own copy of the query, so SetQuery never affects a request already in flight.
 type History struct {
+	mu     sync.RWMutex // guards query
 	query  *HistoryQuery
 	client *Client
 }
 
 func newHistory() *History {
-	return &History{query: &HistoryQuery{}, client: getClient()}
+	return newHistoryWithClient(getClient())
+}
+
+func newHistoryWithClient(client *Client) *History {
+	return &History{query: &HistoryQuery{}, client: client}
 }
 
 func (h *History) SetQuery(query HistoryQuery) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.query = &query
 }
 
+// currentQuery returns a copy of the query set with SetQuery.
+func (h *History) currentQuery() HistoryQuery {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return *h.query
+}
+
 // returns the price/volume history of the given symbol as a YahooHistoryResponse
 // If you want to adjust the query range change h.query.Range = "6mo" for 6 month
 func (h *History) GetHistory(symbol string) (YahooHistoryRespose, error) {
-	h.query.SetDefault()
+	return h.GetHistoryContext(context.Background(), symbol)
+}
+
+// GetHistoryContext is like GetHistory but aborts the request when ctx is cancelled or its deadline passes.
+func (h *History) GetHistoryContext(ctx context.Context, symbol string) (YahooHistoryRespose, error) {
+	return h.getHistory(ctx, symbol, h.currentQuery())
+}
 
+// getHistory fetches the history of symbol for the given query without touching h.query.
+func (h *History) getHistory(ctx context.Context, symbol string, query HistoryQuery) (YahooHistoryRespose, error) {
+	ctx = contextWithSymbol(ctx, symbol)
+	query.SetDefault()
+	if err := query.Validate(); err != nil {
+		return YahooHistoryRespose{}, err
+	}
+
+	chunks, err := historyChunks(query, time.Now())
+	if err != nil {
+		return YahooHistoryRespose{}, err
+	}
+	if len(chunks) > 1 {
+		return h.getHistoryChunks(ctx, symbol, chunks, query.Concurrency)
+	}
+	return h.fetchHistory(ctx, symbol, query)
+}
+
+// fetchHistory makes a single chart request for a query that SetDefault has been applied to.
+func (h *History) fetchHistory(ctx context.Context, symbol string, query HistoryQuery) (YahooHistoryRespose, error) {
 	params := url.Values{}
-	if h.query.Range != "" {
-		params.Add("range", h.query.Range)
+	if query.Range != "" {
+		params.Add("range", string(query.Range))
+	}
+	params.Add("interval", string(query.Interval))
+	if !query.Start.IsZero() {
+		params.Add("period1", strconv.FormatInt(query.Start.Unix(), 10))
+		params.Add("period2", strconv.FormatInt(query.End.Unix(), 10))
+	}
+	params.Add("events", "div,splits,capitalGains")
+	if query.IncludePrePost {
+		params.Add("includePrePost", "true")
 	}
-	params.Add("interval", h.query.Interval)
-	params.Add("period1", h.query.Start)
-	params.Add("period2", h.query.End)
 
-	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s", BASE_URL, symbol)
-	resp, err := h.client.Get(endpoint, params)
+	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s", h.client.baseURL, symbol)
+	resp, err := h.client.GetContext(ctx, endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get history", "err", err)
+		h.client.logger.Error("Failed to get history", "symbol", symbol, "err", err)
 		return YahooHistoryRespose{}, err
 	}
 	defer resp.Body.Close()
 
 	var historyResponse YahooHistoryRespose
-	if err := json.NewDecoder(resp.Body).Decode(&historyResponse); err != nil {
-		log.Fatalf("Failed to decode history data JSON response: %v", err)
+	if err := decodeJSON(resp, &historyResponse); err != nil {
+		return YahooHistoryRespose{}, err
 	}
 
+	if historyResponse.Chart.Error != nil {
+		return YahooHistoryRespose{}, newAPIError(historyResponse.Chart.Error, resp.StatusCode)
+	}
+	if err := checkStatus(resp); err != nil {
+		return YahooHistoryRespose{}, err
+	}
 	if len(historyResponse.Chart.Result) == 0 {
-		return YahooHistoryRespose{}, fmt.Errorf("no data found for symbol: %s", symbol)
+		return YahooHistoryRespose{}, fmt.Errorf("%w: no data found for symbol: %s", ErrSymbolNotFound, symbol)
+	}
+	if err := checkValidRange(historyResponse.Chart.Result[0].Meta, query.Range); err != nil {
+		return YahooHistoryRespose{}, err
 	}
 
 	return historyResponse, nil
 }
 
-func (h *History) transformData(data YahooHistoryRespose) map[string]PriceData {
-	d := make(map[string]PriceData)
-	for i, result := range data.Chart.Result[0].Timestamp {
-		t := time.Unix(result, 0)
-		var key string
-		if strings.HasSuffix(h.query.Interval, "d") || strings.HasSuffix(h.query.Interval, "wk") || strings.HasSuffix(h.query.Interval, "mo") {
-			key = t.Format("2006-01-02")
-		} else {
-			key = t.Format("2006-01-02 15:04:05")
-		}
-		d[key] = PriceData{
-			Open:   data.Chart.Result[0].Indicators.Quote[0].Open[i],
-			High:   data.Chart.Result[0].Indicators.Quote[0].High[i],
-			Low:    data.Chart.Result[0].Indicators.Quote[0].Low[i],
-			Close:  data.Chart.Result[0].Indicators.Quote[0].Close[i],
-			Volume: data.Chart.Result[0].Indicators.Quote[0].Volume[i],
+// checkValidRange returns an error matching ErrInvalidRange if Yahoo lists the ranges it serves
+// for a symbol and rng is not one of them.
+func checkValidRange(meta YahooMeta, rng Range) error {
+	if rng == "" || len(meta.ValidRanges) == 0 {
+		return nil
+	}
+	for _, valid := range meta.ValidRanges {
+		if Range(valid) == rng {
+			return nil
 		}
 	}
-	return d
+	return fmt.Errorf("%w: range %s is not served for %s, valid ranges are %s",
+		ErrInvalidRange, rng, meta.Symbol, strings.Join(meta.ValidRanges, ", "))
+}
+
+func (h *History) transformData(data YahooHistoryRespose) map[string]PriceData {
+	query := h.currentQuery()
+	query.SetDefault()
+	series := newSeries(data.Chart.Result[0], query.Interval)
+	series.applyQuery(query)
+	return series.Map()
 }
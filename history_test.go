@@ -1,6 +1,8 @@
 package yahoofinanceapi
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -13,7 +15,7 @@ func TestNewHistory(t *testing.T) {
 }
 
 func TestGetHistoryValidSymbol(t *testing.T) {
-	history := newHistory()
+	history := newHistoryWithClient(newReplayClient(t, "history"))
 	resp, err := history.GetHistory("AAPL")
 	if err != nil {
 		t.Fatalf("GetHistory returned error: %v", err)
@@ -24,7 +26,7 @@ func TestGetHistoryValidSymbol(t *testing.T) {
 }
 
 func TestGetHistoryInvalidSymbol(t *testing.T) {
-	history := newHistory()
+	history := newHistoryWithClient(newReplayClient(t, "history"))
 	_, err := history.GetHistory("INVALID_SYMBOL_123")
 	if err == nil {
 		t.Error("Expected error for invalid symbol, got nil")
@@ -32,7 +34,7 @@ func TestGetHistoryInvalidSymbol(t *testing.T) {
 }
 
 func TestTransformData(t *testing.T) {
-	history := newHistory()
+	history := newHistoryWithClient(newReplayClient(t, "history"))
 	resp, err := history.GetHistory("AAPL")
 	if err != nil {
 		t.Fatalf("GetHistory returned error: %v", err)
@@ -49,7 +51,7 @@ func TestTransformData(t *testing.T) {
 }
 
 func TestTransformDataWithMinuteInterval(t *testing.T) {
-	history := newHistory()
+	history := newHistoryWithClient(newReplayClient(t, "history"))
 	q := HistoryQuery{Range: "1d", Interval: "1m"}
 	history.SetQuery(q)
 	resp, err := history.GetHistory("AAPL")
@@ -91,21 +93,72 @@ func TestSetDefault(t *testing.T) {
 }
 
 func TestSetDefaultWithStartDate(t *testing.T) {
-	q := HistoryQuery{Start: "2024-01-01"}
+	q := HistoryQuery{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
 	q.SetDefault()
-	_, err := time.Parse("2006-01-02", "2024-01-01")
-	if err != nil {
-		t.Fatal("Test setup error: invalid date")
+	if q.Range != "" {
+		t.Errorf("SetDefault set Range %s for a query with a Start", q.Range)
 	}
-	if q.Start == "default" {
-		t.Error("SetDefault failed to parse valid Start date")
+	if q.End.IsZero() {
+		t.Error("SetDefault did not set End")
 	}
 }
 
-func TestSetDefaultWithInvalidStartDate(t *testing.T) {
-	q := HistoryQuery{Start: "invalid-date"}
-	q.SetDefault()
-	if q.Start != "default" {
-		t.Error("SetDefault did not set Start to 'default' for invalid date")
+func TestGetHistoryInvalidQuery(t *testing.T) {
+	requests := 0
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+	h := newHistoryWithClient(newTestClient(srv))
+	h.SetQuery(HistoryQuery{Interval: "4h"})
+	if _, err := h.GetHistory("AAPL"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request for an invalid query, got %d", requests)
+	}
+}
+
+func TestGetHistoryValidRanges(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"chart":{"result":[{"meta":{"symbol":"NEWCO","validRanges":["1d","5d","1mo","ytd","max"]},"timestamp":[],"indicators":{"quote":[{}]}}],"error":null}}`))
+	})
+	h := newHistoryWithClient(newTestClient(srv))
+	h.SetQuery(HistoryQuery{Range: Range5y})
+	if _, err := h.GetHistory("NEWCO"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange for a range Yahoo does not list, got %v", err)
+	}
+	h.SetQuery(HistoryQuery{Range: RangeYTD})
+	if _, err := h.GetHistory("NEWCO"); err != nil {
+		t.Errorf("Expected a listed range to succeed, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query HistoryQuery
+		valid bool
+	}{
+		{"empty", HistoryQuery{}, true},
+		{"range", HistoryQuery{Range: Range6mo, Interval: Interval1wk}, true},
+		{"period", HistoryQuery{Start: start, End: start.AddDate(0, 1, 0)}, true},
+		{"intraday range", HistoryQuery{Range: Range5d, Interval: Interval1m}, true},
+		{"unknown interval", HistoryQuery{Interval: "4h"}, false},
+		{"unknown range", HistoryQuery{Range: "2mo"}, false},
+		{"end without start", HistoryQuery{End: start}, false},
+		{"range with start", HistoryQuery{Range: Range1y, Start: start}, false},
+		{"end before start", HistoryQuery{Start: start, End: start.AddDate(0, 0, -1)}, false},
+		{"range too long for 1m", HistoryQuery{Range: Range1mo, Interval: Interval1m}, false},
+		{"max intraday", HistoryQuery{Range: RangeMax, Interval: Interval1h}, false},
+	}
+	for _, tt := range tests {
+		err := tt.query.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("%s: expected a valid query, got %v", tt.name, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("%s: expected ErrInvalidRange, got %v", tt.name, err)
+		}
 	}
 }
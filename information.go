@@ -1,10 +1,8 @@
 package yahoofinanceapi
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"log/slog"
 	"net/url"
 )
 
@@ -14,7 +12,7 @@ type YahooInfoResponse struct {
 		Result []struct {
 			Price YahooTickerInfo `json:"price"`
 		} `json:"result"`
-		Error interface{} `json:"error"`
+		Error *YahooError `json:"error"`
 	} `json:"quoteSummary"`
 }
 
@@ -77,42 +75,55 @@ type Information struct {
 	client *Client
 }
 
-// newInformation initializes the Information struct with an HTTP client
+// newInformation initializes the Information struct with the shared HTTP client
 func newInformation() *Information {
-	return &Information{client: getClient()}
+	return newInformationWithClient(getClient())
+}
+
+// newInformationWithClient initializes the Information struct with the given client
+func newInformationWithClient(client *Client) *Information {
+	return &Information{client: client}
 }
 
 // GetInfo fetches metadata information for a given ticker
 func (i *Information) GetInfo(symbol string) (YahooTickerInfo, error) {
+	return i.GetInfoContext(context.Background(), symbol)
+}
+
+// GetInfoContext fetches metadata information for a given ticker, aborting when ctx is cancelled
+func (i *Information) GetInfoContext(ctx context.Context, symbol string) (YahooTickerInfo, error) {
 	// Prepare URL parameters to request the "price" module
 	params := url.Values{}
 	params.Add("modules", "price")
 
 	// Build the endpoint URL for the Yahoo Finance quoteSummary API
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BASE_URL, symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", i.client.baseURL, symbol)
 
 	// Make the HTTP GET request using the client
-	resp, err := i.client.Get(endpoint, params)
+	resp, err := i.client.GetContext(contextWithSymbol(ctx, symbol), endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get ticker info", "err", err)
+		i.client.logger.Error("Failed to get ticker info", "symbol", symbol, "err", err)
 		return YahooTickerInfo{}, err
 	}
+	defer resp.Body.Close()
 
-	// Read the response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return YahooTickerInfo{}, fmt.Errorf("failed to read response body: %w", err)
+	// Decode the JSON response into the YahooInfoResponse struct
+	var infoResponse YahooInfoResponse
+	if err := decodeJSON(resp, &infoResponse); err != nil {
+		return YahooTickerInfo{}, err
 	}
 
-	// Unmarshal the JSON response into the YahooInfoResponse struct
-	var infoResponse YahooInfoResponse
-	if err := json.Unmarshal(bodyBytes, &infoResponse); err != nil {
-		return YahooTickerInfo{}, fmt.Errorf("failed to decode info JSON: %w", err)
+	// Surface the error reported by Yahoo, if any, then any other non-2xx status
+	if infoResponse.QuoteSummary.Error != nil {
+		return YahooTickerInfo{}, newAPIError(infoResponse.QuoteSummary.Error, resp.StatusCode)
+	}
+	if err := checkStatus(resp); err != nil {
+		return YahooTickerInfo{}, err
 	}
 
 	// Check if the result array is empty
 	if len(infoResponse.QuoteSummary.Result) == 0 {
-		return YahooTickerInfo{}, fmt.Errorf("no info found for symbol: %s", symbol)
+		return YahooTickerInfo{}, fmt.Errorf("%w: no info found for symbol: %s", ErrSymbolNotFound, symbol)
 	}
 
 	// Return the ticker price information
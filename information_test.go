@@ -1,9 +1,6 @@
 package yahoofinanceapi
 
-import (
-	"log"
-	"testing"
-)
+import "testing"
 
 func TestNewInformation(t *testing.T) {
 	info := newInformation()
@@ -13,9 +10,8 @@ func TestNewInformation(t *testing.T) {
 }
 
 func TestGetTickerInfoValidSymbol(t *testing.T) {
-	info := newInformation()
+	info := newInformationWithClient(newReplayClient(t, "information"))
 	data, err := info.GetInfo("AAPL")
-	log.Println(data, err)
 	if err != nil {
 		t.Fatalf("GetTickerInfo returned error: %v", err)
 	}
@@ -31,7 +27,7 @@ func TestGetTickerInfoValidSymbol(t *testing.T) {
 }
 
 func TestGetTickerInfoInvalidSymbol(t *testing.T) {
-	info := newInformation()
+	info := newInformationWithClient(newReplayClient(t, "information"))
 	_, err := info.GetInfo("INVALID_SYMBOL_123")
 	if err == nil {
 		t.Error("Expected error for invalid symbol, got nil")
@@ -0,0 +1,98 @@
+package yahoofinanceapi
+
+import (
+	"strings"
+	"time"
+)
+
+// Interval is the length of the bars of a price history.
+type Interval string
+
+// The intervals Yahoo Finance serves.
+const (
+	Interval1m  Interval = "1m"
+	Interval2m  Interval = "2m"
+	Interval5m  Interval = "5m"
+	Interval15m Interval = "15m"
+	Interval30m Interval = "30m"
+	Interval60m Interval = "60m"
+	Interval90m Interval = "90m"
+	Interval1h  Interval = "1h"
+	Interval1d  Interval = "1d"
+	Interval5d  Interval = "5d"
+	Interval1wk Interval = "1wk"
+	Interval1mo Interval = "1mo"
+	Interval3mo Interval = "3mo"
+)
+
+// Intervals lists the intervals Yahoo Finance serves, from the shortest to the longest.
+var Intervals = []Interval{
+	Interval1m, Interval2m, Interval5m, Interval15m, Interval30m, Interval60m, Interval90m,
+	Interval1h, Interval1d, Interval5d, Interval1wk, Interval1mo, Interval3mo,
+}
+
+// Range is a period of price history ending today.
+type Range string
+
+// The ranges Yahoo Finance serves.
+const (
+	Range1d  Range = "1d"
+	Range5d  Range = "5d"
+	Range1mo Range = "1mo"
+	Range3mo Range = "3mo"
+	Range6mo Range = "6mo"
+	Range1y  Range = "1y"
+	Range2y  Range = "2y"
+	Range5y  Range = "5y"
+	Range10y Range = "10y"
+	RangeYTD Range = "ytd"
+	RangeMax Range = "max"
+)
+
+// rangeDurations are the longest periods the ranges span, to check them against intraday limits.
+var rangeDurations = map[Range]time.Duration{
+	Range1d:  day,
+	Range5d:  5 * day,
+	Range1mo: 31 * day,
+	Range3mo: 92 * day,
+	Range6mo: 184 * day,
+	Range1y:  365 * day,
+	Range2y:  730 * day,
+	Range5y:  5 * 365 * day,
+	Range10y: 10 * 365 * day,
+	RangeYTD: 365 * day,
+}
+
+// intradayDurations are the bar lengths of the intraday intervals.
+var intradayDurations = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval2m:  2 * time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval60m: time.Hour,
+	Interval90m: 90 * time.Minute,
+	Interval1h:  time.Hour,
+}
+
+// Valid reports whether Yahoo Finance serves the interval.
+func (i Interval) Valid() bool {
+	for _, valid := range Intervals {
+		if i == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether Yahoo Finance serves the range.
+func (r Range) Valid() bool {
+	_, ok := rangeDurations[r]
+	return ok || r == RangeMax
+}
+
+// isDailyOrLonger reports whether interval is a daily, weekly or monthly interval.
+func isDailyOrLonger(interval Interval) bool {
+	s := string(interval)
+	return strings.HasSuffix(s, "d") || strings.HasSuffix(s, "wk") || strings.HasSuffix(s, "mo")
+}
@@ -0,0 +1,54 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+)
+
+// WithLogger sends the Client's log output to logger. Clients log nothing by default.
+//
+// Every request is logged at debug level, retries at warn level and failures at error level,
+// all with the attributes endpoint, symbol (when known), status and latency.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			logger = slog.New(discardHandler{})
+		}
+		c.logger = logger
+	}
+}
+
+// discardHandler is a slog.Handler that drops every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+type symbolKey struct{}
+
+// contextWithSymbol records the symbol a request is made for, so the Client can log it.
+func contextWithSymbol(ctx context.Context, symbol string) context.Context {
+	return context.WithValue(ctx, symbolKey{}, symbol)
+}
+
+// symbolFromContext returns the symbol stored by contextWithSymbol, if any.
+func symbolFromContext(ctx context.Context) string {
+	symbol, _ := ctx.Value(symbolKey{}).(string)
+	return symbol
+}
+
+// requestAttrs returns the endpoint and symbol attributes logged for every request to rawURL.
+func requestAttrs(ctx context.Context, rawURL string) []any {
+	endpoint := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		endpoint = u.Path
+	}
+	attrs := []any{"endpoint", endpoint}
+	if symbol := symbolFromContext(ctx); symbol != "" {
+		attrs = append(attrs, "symbol", symbol)
+	}
+	return attrs
+}
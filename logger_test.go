@@ -0,0 +1,54 @@
+package yahoofinanceapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClientLogsRequestAttributes(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL"}}],"error":null}}`))
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := NewTickerWithClient("AAPL", newTestClient(srv, WithLogger(logger))).Info(); err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Failed to parse log line %q: %v", line, err)
+		}
+		if record["endpoint"] != "/v10/finance/quoteSummary/AAPL" {
+			continue
+		}
+		found = true
+		if record["symbol"] != "AAPL" {
+			t.Errorf("Expected symbol AAPL, got %v", record["symbol"])
+		}
+		if record["status"] != float64(http.StatusOK) {
+			t.Errorf("Expected status 200, got %v", record["status"])
+		}
+		if _, ok := record["latency"]; !ok {
+			t.Error("Expected latency attribute")
+		}
+	}
+	if !found {
+		t.Errorf("No log record for the quoteSummary request in:\n%s", buf.String())
+	}
+}
+
+func TestClientLogsNothingByDefault(t *testing.T) {
+	c := NewClient()
+	if c.logger.Enabled(context.Background(), slog.LevelError) {
+		t.Error("Expected the default logger to be disabled")
+	}
+}
@@ -0,0 +1,157 @@
+package yahoofinanceapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the request duration histogram.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics counts the requests a Client sends and records their latency, and writes both in the
+// Prometheus text exposition format. Install it with WithMiddleware(metrics.Middleware()) and
+// expose it by registering it as an http.Handler, e.g. at /metrics. It is safe for concurrent use.
+//
+// Requests are counted by endpoint, symbol and status, where status is the HTTP status code or
+// "error" for requests that failed without a response. Latency is recorded by endpoint only.
+type Metrics struct {
+	mu        sync.Mutex
+	requests  map[requestLabels]int64
+	durations map[string]*histogram
+}
+
+type requestLabels struct {
+	endpoint string
+	symbol   string
+	status   string
+}
+
+type histogram struct {
+	counts []int64 // per bucket of DefaultLatencyBuckets, not cumulative
+	count  int64
+	sum    float64
+}
+
+// NewMetrics creates an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:  make(map[requestLabels]int64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+// Middleware returns a Middleware that records every request in m.
+func (m *Metrics) Middleware() Middleware {
+	return HooksMiddleware(Hooks{
+		OnResponse: func(_ *http.Request, info RequestInfo, resp *http.Response, latency time.Duration) {
+			m.observe(info, strconv.Itoa(resp.StatusCode), latency)
+		},
+		OnError: func(_ *http.Request, info RequestInfo, _ error, latency time.Duration) {
+			m.observe(info, "error", latency)
+		},
+	})
+}
+
+func (m *Metrics) observe(info RequestInfo, status string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestLabels{endpoint: info.Endpoint, symbol: info.Symbol, status: status}]++
+
+	h, ok := m.durations[info.Endpoint]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(DefaultLatencyBuckets))}
+		m.durations[info.Endpoint] = h
+	}
+	seconds := latency.Seconds()
+	for i, bound := range DefaultLatencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// RequestCount returns the number of requests sent to endpoint, or to any endpoint if it is empty.
+func (m *Metrics) RequestCount(endpoint string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var total int64
+	for labels, n := range m.requests {
+		if endpoint == "" || labels.endpoint == endpoint {
+			total += n
+		}
+	}
+	return total
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# HELP yahoo_finance_requests_total Requests sent to Yahoo Finance.")
+	fmt.Fprintln(&buf, "# TYPE yahoo_finance_requests_total counter")
+	labels := make([]requestLabels, 0, len(m.requests))
+	for l := range m.requests {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		a, b := labels[i], labels[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		if a.symbol != b.symbol {
+			return a.symbol < b.symbol
+		}
+		return a.status < b.status
+	})
+	for _, l := range labels {
+		fmt.Fprintf(&buf, "yahoo_finance_requests_total{endpoint=%s,symbol=%s,status=%s} %d\n",
+			quoteLabel(l.endpoint), quoteLabel(l.symbol), quoteLabel(l.status), m.requests[l])
+	}
+
+	fmt.Fprintln(&buf, "# HELP yahoo_finance_request_duration_seconds Latency of requests sent to Yahoo Finance.")
+	fmt.Fprintln(&buf, "# TYPE yahoo_finance_request_duration_seconds histogram")
+	endpoints := make([]string, 0, len(m.durations))
+	for endpoint := range m.durations {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		h := m.durations[endpoint]
+		label := quoteLabel(endpoint)
+		var cumulative int64
+		for i, bound := range DefaultLatencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&buf, "yahoo_finance_request_duration_seconds_bucket{endpoint=%s,le=\"%s\"} %d\n",
+				label, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&buf, "yahoo_finance_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", label, h.count)
+		fmt.Fprintf(&buf, "yahoo_finance_request_duration_seconds_sum{endpoint=%s} %s\n", label, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&buf, "yahoo_finance_request_duration_seconds_count{endpoint=%s} %d\n", label, h.count)
+	}
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// quoteLabel quotes a label value, escaping it as the exposition format requires.
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
@@ -0,0 +1,125 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Middleware wraps the http.RoundTripper that sends the Client's requests, e.g. to observe or modify them.
+// Every attempt passes through it, including session bootstrap requests and retries;
+// responses served from the Cache do not.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// WithMiddleware wraps the Client's transport in mw. The first middleware is the outermost one.
+// It can be combined with WithTransport or WithHTTPClient in any order; the middleware always wraps
+// the final transport.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// applyMiddleware wraps the Client's transport in its middleware. NewClient calls it after all options.
+func (c *Client) applyMiddleware() {
+	if len(c.middleware) == 0 {
+		return
+	}
+	transport := c.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		transport = c.middleware[i](transport)
+	}
+	c.client.Transport = transport
+}
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestInfo describes what a request sent by the Client is for.
+type RequestInfo struct {
+	Endpoint string // One of "chart", "options", "quoteSummary", "search", "crumb", "cookie" or "other"
+	Symbol   string // The ticker symbol the request is made for, if any
+}
+
+type endpointKey struct{}
+
+// contextWithEndpoint overrides the endpoint name reported by RequestInfoFromRequest.
+func contextWithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+// RequestInfoFromRequest returns the endpoint and symbol of a request sent by the Client.
+// The endpoint name has low cardinality, so it is suitable as a metric label.
+func RequestInfoFromRequest(req *http.Request) RequestInfo {
+	ctx := req.Context()
+	endpoint, _ := ctx.Value(endpointKey{}).(string)
+	if endpoint == "" {
+		endpoint = endpointName(req.URL.Path)
+	}
+	return RequestInfo{Endpoint: endpoint, Symbol: symbolFromContext(ctx)}
+}
+
+// endpointName maps a request path to the name of the Yahoo Finance endpoint it belongs to.
+func endpointName(path string) string {
+	switch {
+	case strings.Contains(path, "/finance/chart/"):
+		return "chart"
+	case strings.Contains(path, "/finance/options/"):
+		return "options"
+	case strings.Contains(path, "/finance/quoteSummary/"):
+		return "quoteSummary"
+	case strings.HasSuffix(path, "/finance/search"):
+		return "search"
+	case strings.HasSuffix(path, "/getcrumb"):
+		return "crumb"
+	}
+	return "other"
+}
+
+// Hooks are callbacks invoked for every request the Client sends. Nil hooks are skipped.
+type Hooks struct {
+	// OnRequest is called before the request is sent.
+	OnRequest func(req *http.Request, info RequestInfo)
+	// OnResponse is called when a response arrives, whatever its status.
+	OnResponse func(req *http.Request, info RequestInfo, resp *http.Response, latency time.Duration)
+	// OnError is called when the request fails without a response.
+	OnError func(req *http.Request, info RequestInfo, err error, latency time.Duration)
+}
+
+// WithHooks calls hooks for every request the Client sends. It is a shorthand for WithMiddleware(HooksMiddleware(hooks)).
+func WithHooks(hooks Hooks) ClientOption {
+	return WithMiddleware(HooksMiddleware(hooks))
+}
+
+// HooksMiddleware returns a Middleware that calls hooks around every request.
+func HooksMiddleware(hooks Hooks) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info := RequestInfoFromRequest(req)
+			if hooks.OnRequest != nil {
+				hooks.OnRequest(req, info)
+			}
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			latency := time.Since(start)
+			if err != nil {
+				if hooks.OnError != nil {
+					hooks.OnError(req, info, err, latency)
+				}
+				return resp, err
+			}
+			if hooks.OnResponse != nil {
+				hooks.OnResponse(req, info, resp, latency)
+			}
+			return resp, nil
+		})
+	}
+}
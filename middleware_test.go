@@ -0,0 +1,189 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithMiddlewareOrder(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL"}}],"error":null}}`))
+	})
+
+	var calls []string
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	// The transport set after WithMiddleware is still wrapped.
+	c := newTestClient(srv, WithMiddleware(tag("outer"), tag("inner")), WithTransport(http.DefaultTransport))
+	if _, err := NewTickerWithClient("AAPL", c).Info(); err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+	want := []string{"outer", "inner", "outer", "inner", "outer", "inner"} // cookie, crumb, quoteSummary
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected calls %v, got %v", want, calls)
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL"}}],"error":null}}`))
+	})
+
+	var mu sync.Mutex
+	var requests, responses []RequestInfo
+	c := newTestClient(srv, WithHooks(Hooks{
+		OnRequest: func(req *http.Request, info RequestInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, info)
+		},
+		OnResponse: func(req *http.Request, info RequestInfo, resp *http.Response, latency time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			responses = append(responses, info)
+		},
+	}))
+	if _, err := NewTickerWithClient("AAPL", c).Info(); err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+
+	want := []RequestInfo{{Endpoint: "cookie", Symbol: "AAPL"}, {Endpoint: "crumb", Symbol: "AAPL"}, {Endpoint: "quoteSummary", Symbol: "AAPL"}}
+	if len(requests) != len(want) || len(responses) != len(want) {
+		t.Fatalf("Expected %d requests and responses, got %v and %v", len(want), requests, responses)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("Request %d: expected %+v, got %+v", i, want[i], requests[i])
+		}
+	}
+}
+
+func TestHooksOnError(t *testing.T) {
+	failing := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	var got error
+	c := NewClient(WithTransport(failing), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}), WithHooks(Hooks{
+		OnError: func(req *http.Request, info RequestInfo, err error, latency time.Duration) {
+			if info.Endpoint == "quoteSummary" {
+				got = err
+			}
+		},
+	}))
+	if _, err := NewTickerWithClient("AAPL", c).Info(); err == nil {
+		t.Fatal("Expected error")
+	}
+	if got == nil || !strings.Contains(got.Error(), "connection refused") {
+		t.Errorf("Expected OnError with the transport error, got %v", got)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/MISSING") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found"}}}`))
+			return
+		}
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL"}}],"error":null}}`))
+	})
+
+	metrics := NewMetrics()
+	c := newTestClient(srv, WithMiddleware(metrics.Middleware()))
+	NewTickerWithClient("AAPL", c).Info()
+	NewTickerWithClient("AAPL", c).Info()
+	NewTickerWithClient("MISSING", c).Info()
+
+	if n := metrics.RequestCount("quoteSummary"); n != 3 {
+		t.Errorf("Expected 3 quoteSummary requests, got %d", n)
+	}
+	if n := metrics.RequestCount(""); n != 5 {
+		t.Errorf("Expected 5 requests in total, got %d", n)
+	}
+
+	var out strings.Builder
+	if _, err := metrics.WriteTo(&out); err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+	for _, line := range []string{
+		"# TYPE yahoo_finance_requests_total counter",
+		`yahoo_finance_requests_total{endpoint="quoteSummary",symbol="AAPL",status="200"} 2`,
+		`yahoo_finance_requests_total{endpoint="quoteSummary",symbol="MISSING",status="404"} 1`,
+		"# TYPE yahoo_finance_request_duration_seconds histogram",
+		`yahoo_finance_request_duration_seconds_bucket{endpoint="quoteSummary",le="+Inf"} 3`,
+		`yahoo_finance_request_duration_seconds_count{endpoint="quoteSummary"} 3`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, out.String())
+		}
+	}
+}
+
+type testSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttribute(key string, value any) { s.attrs[key] = value }
+func (s *testSpan) RecordError(err error)              { s.err = err }
+func (s *testSpan) End()                               { s.ended = true }
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+type spanKey struct{}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &testSpan{name: name, attrs: map[string]any{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found"}}}`))
+	})
+
+	tracer := &testTracer{}
+	var propagated bool
+	checkContext := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			propagated = req.Context().Value(spanKey{}) != nil
+			return next.RoundTrip(req)
+		})
+	}
+	c := newTestClient(srv, WithMiddleware(TracingMiddleware(tracer), checkContext))
+	NewTickerWithClient("MISSING", c).Info()
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[2]
+	if span.name != "yahoo_finance quoteSummary" {
+		t.Errorf("Unexpected span name %q", span.name)
+	}
+	if span.attrs["yahoo_finance.symbol"] != "MISSING" || span.attrs["http.response.status_code"] != http.StatusNotFound {
+		t.Errorf("Unexpected span attributes %v", span.attrs)
+	}
+	if span.err == nil || !span.ended {
+		t.Errorf("Expected an ended span with an error, got err=%v ended=%v", span.err, span.ended)
+	}
+	if !propagated {
+		t.Error("Expected the span context to reach the next middleware")
+	}
+}
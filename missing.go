@@ -0,0 +1,116 @@
+package yahoofinanceapi
+
+import "math"
+
+// MissingPolicy selects how bars with missing prices are handled. Yahoo reports null prices for
+// intervals without trades, e.g. during a trading halt or in illiquid intraday minutes.
+type MissingPolicy int
+
+const (
+	// MissingDrop removes bars with missing prices. It is the default. The dividends, splits and
+	// capital gains of a removed bar move to the next bar that is kept.
+	MissingDrop MissingPolicy = iota
+	// MissingForwardFill fills missing prices with the close of the previous bar, as if the price
+	// had not moved. Bars before the first complete bar are dropped.
+	MissingForwardFill
+	// MissingKeep keeps bars with missing prices, which are set to NaN.
+	MissingKeep
+)
+
+func (p MissingPolicy) String() string {
+	switch p {
+	case MissingDrop:
+		return "drop"
+	case MissingForwardFill:
+		return "ffill"
+	case MissingKeep:
+		return "keep"
+	default:
+		return "unknown"
+	}
+}
+
+// MissingReport describes the bars of a Series that had missing prices and what became of them.
+type MissingReport struct {
+	Policy     MissingPolicy
+	Incomplete int // Bars with at least one missing price
+	Filled     int // Incomplete bars filled from the previous bar
+	Dropped    int // Incomplete bars removed from the series
+}
+
+// incomplete reports whether any of the bar's prices are missing. A missing volume alone does not
+// make a bar incomplete; it is reported as 0.
+func (b Bar) incomplete() bool {
+	return math.IsNaN(b.Open) || math.IsNaN(b.High) || math.IsNaN(b.Low) || math.IsNaN(b.Close)
+}
+
+// fillMissing applies policy to the incomplete bars and records what it did in s.Missing.
+func (s *Series) fillMissing(policy MissingPolicy) {
+	report := MissingReport{Policy: policy}
+	// Filter in place; newSeries owns the bars.
+	bars := s.Bars[:0]
+	var dropped Bar // The events of the bars dropped since the last kept one
+	for _, bar := range s.Bars {
+		if !bar.incomplete() {
+			bar.addEvents(dropped)
+			dropped = Bar{}
+			bars = append(bars, bar)
+			continue
+		}
+		report.Incomplete++
+		switch {
+		case policy == MissingKeep:
+			bars = append(bars, bar)
+		case policy == MissingForwardFill && len(bars) > 0:
+			bar.fillFrom(bars[len(bars)-1])
+			bars = append(bars, bar)
+			report.Filled++
+		default:
+			// Keep the events of the bar on the next one, so that AdjustVolume still sees its splits.
+			dropped.addEvents(bar)
+			report.Dropped++
+		}
+	}
+	s.Bars = bars
+	s.Missing = report
+}
+
+// addEvents adds the dividends, splits and capital gains of other to b.
+func (b *Bar) addEvents(other Bar) {
+	b.Dividend += other.Dividend
+	b.CapitalGain += other.CapitalGain
+	if other.SplitFactor > 0 {
+		if b.SplitFactor == 0 {
+			b.SplitFactor = 1
+		}
+		b.SplitFactor *= other.SplitFactor
+	}
+}
+
+// fillFrom replaces the missing prices of b with the close of prev.
+func (b *Bar) fillFrom(prev Bar) {
+	for _, price := range []*float64{&b.Open, &b.High, &b.Low, &b.Close} {
+		if math.IsNaN(*price) {
+			*price = prev.Close
+		}
+	}
+	if math.IsNaN(b.AdjClose) {
+		b.AdjClose = prev.AdjClose
+	}
+}
+
+// floatAt returns the i-th value of a nullable chart array, or NaN if it is null or missing.
+func floatAt(values []*float64, i int) float64 {
+	if i >= len(values) || values[i] == nil {
+		return math.NaN()
+	}
+	return *values[i]
+}
+
+// intAt returns the i-th value of a nullable chart array, or 0 if it is null or missing.
+func intAt(values []*int64, i int) int64 {
+	if i >= len(values) || values[i] == nil {
+		return 0
+	}
+	return *values[i]
+}
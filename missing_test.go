@@ -0,0 +1,123 @@
+package yahoofinanceapi
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// testMissingChartJSON has a halted second minute, a minute with only a close and a last
+// minute cut short of volume and adjusted close.
+const testMissingChartJSON = `{
+	"meta":{"symbol":"AAPL","dataGranularity":"1m"},
+	"timestamp":[1704205800,1704205860,1704205920,1704205980],
+	"indicators":{
+		"quote":[{
+			"open":[187.15,null,186.5,186.9],
+			"high":[187.5,null,187.0,187.2],
+			"low":[186.9,null,186.2,186.8],
+			"close":[187.0,null,null,187.1],
+			"volume":[120000,null,5000]
+		}],
+		"adjclose":[{"adjclose":[186.5,null,null]}]
+	}
+}`
+
+func testMissingSeries(t *testing.T) Series {
+	t.Helper()
+	var result YahooHistoryResult
+	if err := json.Unmarshal([]byte(testMissingChartJSON), &result); err != nil {
+		t.Fatalf("Failed to decode chart with nulls: %v", err)
+	}
+	return newSeries(result, "1m")
+}
+
+func TestNewSeriesNulls(t *testing.T) {
+	s := testMissingSeries(t)
+	if s.Len() != 4 {
+		t.Fatalf("Expected 4 bars, got %d", s.Len())
+	}
+	if halted := s.Bars[1]; !math.IsNaN(halted.Open) || !math.IsNaN(halted.Close) || halted.Volume != 0 {
+		t.Errorf("Expected NaN prices and no volume for the halted bar, got %+v", halted.PriceData)
+	}
+	if last := s.Bars[3]; last.Volume != 0 || last.AdjClose != last.Close {
+		t.Errorf("Expected a short volume array to read as 0 and AdjClose to fall back to Close, got %+v", last.PriceData)
+	}
+}
+
+func TestSeriesFillMissing(t *testing.T) {
+	tests := []struct {
+		policy     MissingPolicy
+		want       MissingReport
+		wantCloses []float64
+	}{
+		{MissingDrop, MissingReport{Policy: MissingDrop, Incomplete: 2, Dropped: 2}, []float64{187.0, 187.1}},
+		{MissingForwardFill, MissingReport{Policy: MissingForwardFill, Incomplete: 2, Filled: 2}, []float64{187.0, 187.0, 187.0, 187.1}},
+		{MissingKeep, MissingReport{Policy: MissingKeep, Incomplete: 2}, []float64{187.0, math.NaN(), math.NaN(), 187.1}},
+	}
+	for _, tt := range tests {
+		s := testMissingSeries(t)
+		s.fillMissing(tt.policy)
+		if s.Missing != tt.want {
+			t.Errorf("%s: expected report %+v, got %+v", tt.policy, tt.want, s.Missing)
+		}
+		if s.Len() != len(tt.wantCloses) {
+			t.Errorf("%s: expected %d bars, got %d", tt.policy, len(tt.wantCloses), s.Len())
+			continue
+		}
+		for i, want := range tt.wantCloses {
+			if got := s.Bars[i].Close; got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+				t.Errorf("%s: bar %d: expected close %v, got %v", tt.policy, i, want, got)
+			}
+		}
+	}
+
+	// Forward filling keeps the prices Yahoo did report.
+	s := testMissingSeries(t)
+	s.fillMissing(MissingForwardFill)
+	want := PriceData{Open: 186.5, High: 187.0, Low: 186.2, Close: 187.0, AdjClose: 186.5, Volume: 5000}
+	if s.Bars[2].PriceData != want {
+		t.Errorf("Expected %+v, got %+v", want, s.Bars[2].PriceData)
+	}
+}
+
+func TestSeriesFillMissingLeadingGap(t *testing.T) {
+	result := testSeriesResult()
+	result.Indicators.Quote[0].Close[0] = nil
+	s := newSeries(result, "1d")
+	s.fillMissing(MissingForwardFill)
+	if s.Len() != 2 || s.Missing.Dropped != 1 || s.Missing.Filled != 0 {
+		t.Errorf("Expected the leading bar without a previous close to be dropped, got %d bars and %+v", s.Len(), s.Missing)
+	}
+}
+
+func TestSeriesFillMissingKeepsEvents(t *testing.T) {
+	// A 2:1 split and a dividend on a day Yahoo reports without prices.
+	const chart = `{
+		"meta":{"symbol":"AAPL","dataGranularity":"1d","exchangeTimezoneName":"America/New_York"},
+		"timestamp":[1704205800,1704292200,1704378600],
+		"indicators":{"quote":[{
+			"open":[1,null,3],"high":[1,null,3],"low":[1,null,3],"close":[1,null,3],"volume":[100,null,300]
+		}]},
+		"events":{
+			"splits":{"1704292200":{"date":1704292200,"numerator":2,"denominator":1,"splitRatio":"2:1"}},
+			"dividends":{"1704292200":{"date":1704292200,"amount":0.25}}
+		}
+	}`
+	var result YahooHistoryResult
+	if err := json.Unmarshal([]byte(chart), &result); err != nil {
+		t.Fatalf("Failed to decode chart: %v", err)
+	}
+	s := newSeries(result, "1d")
+	s.applyQuery(HistoryQuery{AdjustVolume: true})
+
+	if s.Len() != 2 || s.Missing.Dropped != 1 {
+		t.Fatalf("Expected the day without prices to be dropped, got %d bars and %+v", s.Len(), s.Missing)
+	}
+	if s.Bars[0].Volume != 200 {
+		t.Errorf("Expected the volume before the split to be adjusted to 200, got %d", s.Bars[0].Volume)
+	}
+	if next := s.Bars[1]; next.SplitFactor != 2 || next.Dividend != 0.25 || next.Volume != 300 {
+		t.Errorf("Expected the events of the dropped day on the next bar, got %+v", next)
+	}
+}
@@ -1,9 +1,8 @@
 package yahoofinanceapi
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"log/slog"
 	"net/url"
 	"time"
 )
@@ -14,7 +13,7 @@ type YahooOptionResponse struct {
 
 type YahooOptionChain struct {
 	Result []YahooOptionResult `json:"result"`
-	Error  any                 `json:"error"`
+	Error  *YahooError         `json:"error"`
 }
 
 type YahooOptionResult struct {
@@ -170,55 +169,81 @@ type Option struct {
 }
 
 func newOption() *Option {
-	return &Option{client: getClient()}
+	return newOptionWithClient(getClient())
 }
 
-func (o *Option) GetOptionChain(symbol string) YahooOptionResponse {
-	endpoint := fmt.Sprintf("%s/v7/finance/options/%s", BASE_URL, symbol)
-	resp, err := o.client.Get(endpoint, url.Values{})
-	if err != nil {
-		slog.Error("Failed to get option chain", "err", err)
-		return YahooOptionResponse{}
-	}
-	defer resp.Body.Close()
+func newOptionWithClient(client *Client) *Option {
+	return &Option{client: client}
+}
 
-	var optionResponse YahooOptionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&optionResponse); err != nil {
-		slog.Error("Failed to decode option data JSON response", "err", err)
-	}
+// GetOptionChain returns the option chain of the nearest expiration date for the given symbol.
+func (o *Option) GetOptionChain(symbol string) (YahooOptionResponse, error) {
+	return o.GetOptionChainContext(context.Background(), symbol)
+}
+
+// GetOptionChainContext is like GetOptionChain but aborts the request when ctx is cancelled.
+func (o *Option) GetOptionChainContext(ctx context.Context, symbol string) (YahooOptionResponse, error) {
+	return o.getOptionChain(ctx, symbol, url.Values{})
+}
 
-	return optionResponse
+// GetOptionChainByExpiration returns the option chain of the given symbol for an expiration date formatted as 2006-01-02.
+func (o *Option) GetOptionChainByExpiration(symbol string, expirationDate string) (YahooOptionResponse, error) {
+	return o.GetOptionChainByExpirationContext(context.Background(), symbol, expirationDate)
 }
 
-func (o *Option) GetOptionChainByExpiration(symbol string, expirationDate string) YahooOptionResponse {
+// GetOptionChainByExpirationContext is like GetOptionChainByExpiration but aborts the request when ctx is cancelled.
+func (o *Option) GetOptionChainByExpirationContext(ctx context.Context, symbol string, expirationDate string) (YahooOptionResponse, error) {
 	t, err := time.Parse("2006-01-02", expirationDate)
 	if err != nil {
-		slog.Error("Failed to parse expiration date", "err", err)
-		return YahooOptionResponse{}
+		return YahooOptionResponse{}, fmt.Errorf("failed to parse expiration date: %w", err)
 	}
-	endpoint := fmt.Sprintf("%s/v7/finance/options/%s", BASE_URL, symbol)
 	params := url.Values{}
 	params.Add("date", fmt.Sprintf("%d", t.Unix()))
-	resp, err := o.client.Get(endpoint, params)
+	return o.getOptionChain(ctx, symbol, params)
+}
+
+// getOptionChain requests the options endpoint and checks that Yahoo returned a result for symbol.
+func (o *Option) getOptionChain(ctx context.Context, symbol string, params url.Values) (YahooOptionResponse, error) {
+	ctx = contextWithSymbol(ctx, symbol)
+	endpoint := fmt.Sprintf("%s/v7/finance/options/%s", o.client.baseURL, symbol)
+	resp, err := o.client.GetContext(ctx, endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get option chain by expiration", "err", err)
-		return YahooOptionResponse{}
+		o.client.logger.Error("Failed to get option chain", "symbol", symbol, "err", err)
+		return YahooOptionResponse{}, err
 	}
 	defer resp.Body.Close()
 
 	var optionResponse YahooOptionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&optionResponse); err != nil {
-		slog.Error("Failed to decode option data JSON response", "err", err)
+	if err := decodeJSON(resp, &optionResponse); err != nil {
+		return YahooOptionResponse{}, err
+	}
+
+	if optionResponse.OptionChain.Error != nil {
+		return YahooOptionResponse{}, newAPIError(optionResponse.OptionChain.Error, resp.StatusCode)
+	}
+	if err := checkStatus(resp); err != nil {
+		return YahooOptionResponse{}, err
+	}
+	if len(optionResponse.OptionChain.Result) == 0 {
+		return YahooOptionResponse{}, fmt.Errorf("%w: no option chain found for symbol: %s", ErrSymbolNotFound, symbol)
 	}
 
-	return optionResponse
+	return optionResponse, nil
 }
 
-func (o *Option) transformData(data YahooOptionResponse) OptionData {
-	date := time.Unix(data.OptionChain.Result[0].Options[0].ExpirationDate, 0).Format("2006-01-02")
+func (o *Option) transformData(data YahooOptionResponse) (OptionData, error) {
+	if len(data.OptionChain.Result) == 0 {
+		return OptionData{}, fmt.Errorf("%w: empty option chain response", ErrSymbolNotFound)
+	}
+	result := data.OptionChain.Result[0]
+	if len(result.Options) == 0 {
+		return OptionData{}, fmt.Errorf("%w for symbol: %s", ErrNoOptions, result.UnderlyingSymbol)
+	}
+
+	date := time.Unix(result.Options[0].ExpirationDate, 0).UTC().Format("2006-01-02")
 	var calls []OptionDetail
 	var puts []OptionDetail
-	for _, call := range data.OptionChain.Result[0].Options[0].Calls {
+	for _, call := range result.Options[0].Calls {
 		calls = append(calls, OptionDetail{
 			ContractSymbol:    call.ContractSymbol,
 			Strike:            call.Strike,
@@ -231,13 +256,13 @@ func (o *Option) transformData(data YahooOptionResponse) OptionData {
 			Bid:               call.Bid,
 			Ask:               call.Ask,
 			ContractSize:      call.ContractSize,
-			Expiration:        time.Unix(call.Expiration, 0).Format("2006-01-02"),
+			Expiration:        time.Unix(call.Expiration, 0).UTC().Format("2006-01-02"),
 			LastTradeDate:     time.Unix(call.LastTradeDate, 0).Format("2006-01-02"),
 			ImpliedVolatility: call.ImpliedVolatility,
 			InTheMoney:        call.InTheMoney,
 		})
 	}
-	for _, put := range data.OptionChain.Result[0].Options[0].Puts {
+	for _, put := range result.Options[0].Puts {
 		puts = append(puts, OptionDetail{
 			ContractSymbol:    put.ContractSymbol,
 			Strike:            put.Strike,
@@ -250,7 +275,7 @@ func (o *Option) transformData(data YahooOptionResponse) OptionData {
 			Bid:               put.Bid,
 			Ask:               put.Ask,
 			ContractSize:      put.ContractSize,
-			Expiration:        time.Unix(put.Expiration, 0).Format("2006-01-02"),
+			Expiration:        time.Unix(put.Expiration, 0).UTC().Format("2006-01-02"),
 			LastTradeDate:     time.Unix(put.LastTradeDate, 0).Format("2006-01-02"),
 			ImpliedVolatility: put.ImpliedVolatility,
 			InTheMoney:        put.InTheMoney,
@@ -258,17 +283,30 @@ func (o *Option) transformData(data YahooOptionResponse) OptionData {
 	}
 	return OptionData{
 		ExpirationDate: date,
-		HasMiniOptions: data.OptionChain.Result[0].HasMiniOptions,
+		HasMiniOptions: result.HasMiniOptions,
 		Calls:          calls,
 		Puts:           puts,
-	}
+	}, nil
+}
+
+// GetExpirationDates returns the option expiration dates of the given symbol formatted as 2006-01-02.
+func (o *Option) GetExpirationDates(symbol string) ([]string, error) {
+	return o.GetExpirationDatesContext(context.Background(), symbol)
 }
 
-func (o *Option) GetExpirationDates(symbol string) []string {
-	optionChain := o.GetOptionChain(symbol)
+// GetExpirationDatesContext is like GetExpirationDates but aborts the request when ctx is cancelled.
+func (o *Option) GetExpirationDatesContext(ctx context.Context, symbol string) ([]string, error) {
+	optionChain, err := o.GetOptionChainContext(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
 	var expirationDates []string
+	// Expirations are midnight UTC; formatting them in UTC keeps the dates valid input for GetOptionChainByExpiration.
 	for _, date := range optionChain.OptionChain.Result[0].ExpirationDates {
-		expirationDates = append(expirationDates, time.Unix(date, 0).Format("2006-01-02"))
+		expirationDates = append(expirationDates, time.Unix(date, 0).UTC().Format("2006-01-02"))
+	}
+	if len(expirationDates) == 0 {
+		return nil, fmt.Errorf("%w for symbol: %s", ErrNoOptions, symbol)
 	}
-	return expirationDates
+	return expirationDates, nil
 }
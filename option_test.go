@@ -0,0 +1,93 @@
+package yahoofinanceapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const testOptionChainJSON = `{"optionChain":{"result":[{"underlyingSymbol":"AAPL","expirationDates":[1705622400,1706227200],"strikes":[180,185],"hasMiniOptions":false,"quote":{"symbol":"AAPL"},"options":[{"expirationDate":1705622400,"hasMiniOptions":false,"calls":[{"contractSymbol":"AAPL240119C00180000","strike":180,"currency":"USD","lastPrice":5.2,"volume":1200,"openInterest":3400,"bid":5.1,"ask":5.3,"contractSize":"REGULAR","expiration":1705622400,"lastTradeDate":1705006800,"impliedVolatility":0.21,"inTheMoney":true}],"puts":[{"contractSymbol":"AAPL240119P00185000","strike":185,"currency":"USD","lastPrice":3.1,"volume":800,"openInterest":2100,"bid":3.0,"ask":3.2,"contractSize":"REGULAR","expiration":1705622400,"lastTradeDate":1705006800,"impliedVolatility":0.23,"inTheMoney":true}]}]}],"error":null}}`
+
+const testNoOptionsJSON = `{"optionChain":{"result":[{"underlyingSymbol":"BRK-A","expirationDates":[],"strikes":[],"hasMiniOptions":false,"quote":{"symbol":"BRK-A"},"options":[]}],"error":null}}`
+
+func newOptionTestServer(t *testing.T) *Client {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v7/finance/options/AAPL":
+			w.Write([]byte(testOptionChainJSON))
+		case "/v7/finance/options/BRK-A":
+			w.Write([]byte(testNoOptionsJSON))
+		default:
+			w.Write([]byte(`{"optionChain":{"result":[],"error":null}}`))
+		}
+	})
+	return newTestClient(srv)
+}
+
+func TestTickerOptionChain(t *testing.T) {
+	ticker := NewTickerWithClient("AAPL", newOptionTestServer(t))
+	data, err := ticker.OptionChain()
+	if err != nil {
+		t.Fatalf("OptionChain returned error: %v", err)
+	}
+	if len(data.Calls) != 1 || len(data.Puts) != 1 {
+		t.Fatalf("Expected 1 call and 1 put, got %d and %d", len(data.Calls), len(data.Puts))
+	}
+	if data.Calls[0].ContractSymbol != "AAPL240119C00180000" {
+		t.Errorf("Unexpected call contract %s", data.Calls[0].ContractSymbol)
+	}
+
+	dates, err := ticker.ExpirationDates()
+	if err != nil {
+		t.Fatalf("ExpirationDates returned error: %v", err)
+	}
+	if len(dates) != 2 {
+		t.Errorf("Expected 2 expiration dates, got %v", dates)
+	}
+}
+
+func TestTickerOptionChainWithoutOptions(t *testing.T) {
+	ticker := NewTickerWithClient("BRK-A", newOptionTestServer(t))
+	if _, err := ticker.OptionChain(); !errors.Is(err, ErrNoOptions) {
+		t.Errorf("Expected ErrNoOptions from OptionChain, got %v", err)
+	}
+	if _, err := ticker.ExpirationDates(); !errors.Is(err, ErrNoOptions) {
+		t.Errorf("Expected ErrNoOptions from ExpirationDates, got %v", err)
+	}
+}
+
+func TestTickerOptionChainUnknownSymbol(t *testing.T) {
+	ticker := NewTickerWithClient("INVALID_SYMBOL_123", newOptionTestServer(t))
+	if _, err := ticker.OptionChain(); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from OptionChain, got %v", err)
+	}
+	if _, err := ticker.OptionChainByExpiration("2024-01-19"); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from OptionChainByExpiration, got %v", err)
+	}
+	if _, err := ticker.ExpirationDates(); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from ExpirationDates, got %v", err)
+	}
+}
+
+func TestOptionChainByExpirationInvalidDate(t *testing.T) {
+	ticker := NewTickerWithClient("AAPL", newOptionTestServer(t))
+	if _, err := ticker.OptionChainByExpiration("19/01/2024"); err == nil {
+		t.Error("Expected error for malformed expiration date, got nil")
+	}
+}
+
+func TestOptionChainNetworkError(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	c := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	srv.Close()
+
+	if _, err := NewTickerWithClient("AAPL", c).OptionChain(); err == nil {
+		t.Error("Expected error when the server is unreachable, got nil")
+	}
+}
+
+func TestOptionTransformDataEmptyResponse(t *testing.T) {
+	if _, err := newOption().transformData(YahooOptionResponse{}); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package yahoofinanceapi
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// JoinMode selects the timestamps of a Panel.
+type JoinMode int
+
+const (
+	JoinOuter JoinMode = iota // Every timestamp any of the series has a bar at
+	JoinInner                 // Only the timestamps all of the series have a bar at
+)
+
+// Field is a column of price data.
+type Field string
+
+// The fields of a Bar a Panel has columns for.
+const (
+	FieldOpen     Field = "open"
+	FieldHigh     Field = "high"
+	FieldLow      Field = "low"
+	FieldClose    Field = "close"
+	FieldAdjClose Field = "adjclose"
+	FieldVolume   Field = "volume"
+)
+
+// value returns the field of b, or NaN for an unknown field or the volume of a missing bar.
+func (f Field) value(b Bar) float64 {
+	switch f {
+	case FieldOpen:
+		return b.Open
+	case FieldHigh:
+		return b.High
+	case FieldLow:
+		return b.Low
+	case FieldClose:
+		return b.Close
+	case FieldAdjClose:
+		return b.AdjClose
+	case FieldVolume:
+		if b.incomplete() {
+			return math.NaN()
+		}
+		return float64(b.Volume)
+	}
+	return math.NaN()
+}
+
+// PanelOptions configures how NewPanel aligns the series.
+type PanelOptions struct {
+	Join JoinMode
+	// ForwardFill fills the timestamps a series has no bar at with flat bars at its previous close,
+	// as MissingForwardFill does. Timestamps before the first bar of the series stay missing.
+	ForwardFill bool
+}
+
+// Panel is the price history of several symbols aligned on a common index of timestamps.
+// A symbol without a bar at a timestamp has a bar with NaN prices and no volume there.
+type Panel struct {
+	Index    []time.Time
+	Symbols  []string       // In alphabetical order
+	Interval Interval       // The bar interval of all the series
+	Location *time.Location // The timezone of the index: that of the series, or UTC if they differ
+	bars     map[string][]Bar
+}
+
+// NewPanel aligns the series of each symbol, e.g. the Series of a DownloadResult, on their timestamps.
+// Daily or longer bars are aligned by trading date, so that markets in different timezones line up;
+// intraday bars by instant. All the series must have the same interval.
+func NewPanel(series map[string]Series, opts PanelOptions) (Panel, error) {
+	p := Panel{Location: time.UTC, bars: make(map[string][]Bar, len(series))}
+	for symbol := range series {
+		p.Symbols = append(p.Symbols, symbol)
+	}
+	sort.Strings(p.Symbols)
+	for i, symbol := range p.Symbols {
+		s := series[symbol]
+		if i == 0 {
+			p.Interval, p.Location = s.Interval, s.Location
+			continue
+		}
+		if s.Interval != p.Interval {
+			return Panel{}, fmt.Errorf("cannot align %s bars of %s with %s bars of %s", s.Interval, symbol, p.Interval, p.Symbols[0])
+		}
+		if s.Location == nil || p.Location == nil || s.Location.String() != p.Location.String() {
+			p.Location = time.UTC
+		}
+	}
+	if p.Location == nil {
+		p.Location = time.UTC
+	}
+	daily := isDailyOrLonger(p.Interval)
+	key := func(t time.Time) int64 {
+		if daily {
+			return dayNumber(t)
+		}
+		return t.Unix()
+	}
+
+	// Count the series with a bar at each key; an inner join keeps the keys all of them have.
+	counts := make(map[int64]int)
+	for _, symbol := range p.Symbols {
+		for _, bar := range series[symbol].Bars {
+			counts[key(bar.Time)]++
+		}
+	}
+	var keys []int64
+	for k, n := range counts {
+		if opts.Join == JoinOuter || n == len(p.Symbols) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	rows := make(map[int64]int, len(keys))
+	p.Index = make([]time.Time, len(keys))
+	for i, k := range keys {
+		rows[k] = i
+		if daily {
+			year, month, day := time.Unix(k*24*60*60, 0).UTC().Date()
+			p.Index[i] = time.Date(year, month, day, 0, 0, 0, 0, p.Location)
+		} else {
+			p.Index[i] = time.Unix(k, 0).In(p.Location)
+		}
+	}
+
+	nan := math.NaN()
+	for _, symbol := range p.Symbols {
+		bars := make([]Bar, len(keys))
+		present := make([]bool, len(keys))
+		for _, bar := range series[symbol].Bars {
+			if i, ok := rows[key(bar.Time)]; ok {
+				bars[i], present[i] = bar, true
+			}
+		}
+		for i := range bars {
+			if present[i] {
+				bars[i].Time = p.Index[i]
+				continue
+			}
+			bars[i] = Bar{Time: p.Index[i], PriceData: PriceData{Open: nan, High: nan, Low: nan, Close: nan, AdjClose: nan}}
+			if opts.ForwardFill && i > 0 {
+				bars[i].fillFrom(bars[i-1])
+			}
+		}
+		p.bars[symbol] = bars
+	}
+	return p, nil
+}
+
+// Len returns the number of timestamps in the panel.
+func (p Panel) Len() int {
+	return len(p.Index)
+}
+
+// Bars returns the bars of symbol aligned with the index, or nil if the panel has no such symbol.
+// The returned slice must not be modified.
+func (p Panel) Bars(symbol string) []Bar {
+	return p.bars[symbol]
+}
+
+// Column returns the field of symbol at each timestamp of the index, or nil if the panel has no such symbol.
+// Missing values are NaN.
+func (p Panel) Column(symbol string, field Field) []float64 {
+	bars, ok := p.bars[symbol]
+	if !ok {
+		return nil
+	}
+	column := make([]float64, len(bars))
+	for i, bar := range bars {
+		column[i] = field.value(bar)
+	}
+	return column
+}
+
+// Columns returns the Column of field for every symbol, e.g. Columns(FieldClose) for all the closes.
+func (p Panel) Columns(field Field) map[string][]float64 {
+	columns := make(map[string][]float64, len(p.Symbols))
+	for _, symbol := range p.Symbols {
+		columns[symbol] = p.Column(symbol, field)
+	}
+	return columns
+}
+
+// WriteCSV writes the panel to w as CSV, one row per timestamp, with a "time" column followed by
+// a column per symbol and field. With a single field, which defaults to FieldClose, the columns are
+// named after the symbols; with several, they are named "<symbol>.<field>", e.g. "AAPL.close".
+// Daily or longer timestamps are written as dates, others in RFC 3339. Missing values are empty.
+func (p Panel) WriteCSV(w io.Writer, fields ...Field) error {
+	if len(fields) == 0 {
+		fields = []Field{FieldClose}
+	}
+	layout := time.RFC3339
+	if isDailyOrLonger(p.Interval) {
+		layout = "2006-01-02"
+	}
+
+	cw := csv.NewWriter(w)
+	header := []string{"time"}
+	for _, symbol := range p.Symbols {
+		for _, field := range fields {
+			if len(fields) == 1 {
+				header = append(header, symbol)
+			} else {
+				header = append(header, symbol+"."+string(field))
+			}
+		}
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for i, t := range p.Index {
+		record := []string{t.Format(layout)}
+		for _, symbol := range p.Symbols {
+			for _, field := range fields {
+				record = append(record, formatFloat(field.value(p.bars[symbol][i]), ShortestPrecision))
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
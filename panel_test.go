@@ -0,0 +1,117 @@
+package yahoofinanceapi
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dailySeries returns a daily series in loc with a bar closing at close on each of the given days of January 2025.
+func dailySeries(symbol string, loc *time.Location, closes map[int]float64) Series {
+	s := Series{Symbol: symbol, Interval: Interval1d, Location: loc}
+	for day := 1; day <= 31; day++ {
+		if c, ok := closes[day]; ok {
+			bar := Bar{Time: time.Date(2025, 1, day, 0, 0, 0, 0, loc), PriceData: PriceData{Open: c, High: c, Low: c, Close: c, AdjClose: c, Volume: 10}}
+			s.Bars = append(s.Bars, bar)
+		}
+	}
+	return s
+}
+
+func TestNewPanelJoins(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	series := map[string]Series{
+		"AAPL":   dailySeries("AAPL", ny, map[int]float64{2: 1, 3: 2, 6: 3}),
+		"7203.T": dailySeries("7203.T", tokyo, map[int]float64{3: 10, 6: 11, 7: 12}),
+	}
+
+	outer, err := NewPanel(series, PanelOptions{})
+	if err != nil {
+		t.Fatalf("NewPanel returned error: %v", err)
+	}
+	if outer.Len() != 4 || outer.Symbols[0] != "7203.T" || outer.Location != time.UTC {
+		t.Fatalf("Expected 4 UTC dates of 7203.T and AAPL, got %v of %v in %v", outer.Index, outer.Symbols, outer.Location)
+	}
+	if !outer.Index[0].Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the index to start on 2025-01-02, got %v", outer.Index[0])
+	}
+	closes := outer.Columns(FieldClose)
+	if !math.IsNaN(closes["7203.T"][0]) || closes["7203.T"][1] != 10 || !math.IsNaN(closes["AAPL"][3]) {
+		t.Errorf("Unexpected outer join closes %v", closes)
+	}
+	if volume := outer.Column("AAPL", FieldVolume); volume[0] != 10 || !math.IsNaN(volume[3]) {
+		t.Errorf("Expected missing volumes to be NaN, got %v", volume)
+	}
+	if outer.Column("MSFT", FieldClose) != nil {
+		t.Error("Expected no column for a missing symbol")
+	}
+
+	inner, err := NewPanel(series, PanelOptions{Join: JoinInner})
+	if err != nil {
+		t.Fatalf("NewPanel returned error: %v", err)
+	}
+	if inner.Len() != 2 || inner.Column("AAPL", FieldClose)[1] != 3 || inner.Column("7203.T", FieldClose)[0] != 10 {
+		t.Errorf("Expected the closes of 3 and 6 January, got %v", inner.Columns(FieldClose))
+	}
+
+	filled, err := NewPanel(series, PanelOptions{ForwardFill: true})
+	if err != nil {
+		t.Fatalf("NewPanel returned error: %v", err)
+	}
+	bar := filled.Bars("AAPL")[3]
+	if bar.Open != 3 || bar.Close != 3 || bar.Volume != 0 || !bar.Time.Equal(filled.Index[3]) {
+		t.Errorf("Expected a flat bar at the previous close, got %+v", bar)
+	}
+	if !math.IsNaN(filled.Column("7203.T", FieldClose)[0]) {
+		t.Error("Expected no fill before the first bar")
+	}
+}
+
+func TestNewPanelIntraday(t *testing.T) {
+	start := time.Date(2025, 1, 6, 14, 30, 0, 0, time.UTC)
+	a := Series{Interval: Interval1m, Location: time.UTC, Bars: testBars(start, time.Minute, 3, SessionRegular)}
+	b := Series{Interval: Interval1m, Location: time.UTC, Bars: testBars(start.Add(time.Minute), time.Minute, 3, SessionRegular)}
+	p, err := NewPanel(map[string]Series{"A": a, "B": b}, PanelOptions{Join: JoinInner})
+	if err != nil {
+		t.Fatalf("NewPanel returned error: %v", err)
+	}
+	if p.Len() != 2 || !p.Index[0].Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the 2 minutes both series have, got %v", p.Index)
+	}
+
+	b.Interval = Interval5m
+	if _, err := NewPanel(map[string]Series{"A": a, "B": b}, PanelOptions{}); err == nil {
+		t.Error("Expected an error aligning 1m and 5m bars")
+	}
+}
+
+func TestPanelWriteCSV(t *testing.T) {
+	series := map[string]Series{
+		"AAPL": dailySeries("AAPL", time.UTC, map[int]float64{2: 1.5, 3: 2}),
+		"MSFT": dailySeries("MSFT", time.UTC, map[int]float64{3: 400.25}),
+	}
+	p, err := NewPanel(series, PanelOptions{})
+	if err != nil {
+		t.Fatalf("NewPanel returned error: %v", err)
+	}
+
+	var b strings.Builder
+	if err := p.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	want := "time,AAPL,MSFT\n2025-01-02,1.5,\n2025-01-03,2,400.25\n"
+	if b.String() != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	b.Reset()
+	if err := p.WriteCSV(&b, FieldClose, FieldVolume); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	want = "time,AAPL.close,AAPL.volume,MSFT.close,MSFT.volume\n2025-01-02,1.5,10,,\n2025-01-03,2,10,400.25,10\n"
+	if b.String() != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, b.String())
+	}
+}
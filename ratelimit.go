@@ -0,0 +1,125 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that allows up to Burst requests at once and refills
+// at a steady rate of requests per second. It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter creates a limiter that allows requestsPerSecond on average with bursts of up to burst requests.
+// A burst below 1 is treated as 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// WithRateLimit throttles every request the Client sends, across history, options, info and search calls.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithHostRateLimit adds a limit for requests to a single host (e.g. "query2.finance.yahoo.com").
+// It applies on top of the limit set by WithRateLimit.
+func WithHostRateLimit(host string, requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		if c.hostLimiters == nil {
+			c.hostLimiters = make(map[string]*RateLimiter)
+		}
+		c.hostLimiters[host] = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// refill adds the tokens accumulated since the last call. The caller must hold l.mu.
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// delayFor returns how long to wait until the bucket holds one token. The caller must hold l.mu.
+func (l *RateLimiter) delayFor() time.Duration {
+	if l.tokens >= 1 {
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+// The request's token is reserved up front, so callers are served in the order they arrived.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.refill(l.now())
+	delay := l.delayFor()
+	l.tokens--
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Give the reservation back so cancelled callers do not slow down the others.
+		l.mu.Lock()
+		l.tokens = math.Min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// WaitTime reports how long a request made now would have to wait for a token.
+func (l *RateLimiter) WaitTime() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(l.now())
+	return l.delayFor()
+}
+
+// RateLimitWait reports how long a request to host would currently wait for the Client's rate limiters.
+// It returns 0 when no limiter is configured.
+func (c *Client) RateLimitWait(host string) time.Duration {
+	var wait time.Duration
+	if c.limiter != nil {
+		wait = c.limiter.WaitTime()
+	}
+	if limiter, ok := c.hostLimiters[host]; ok {
+		wait = max(wait, limiter.WaitTime())
+	}
+	return wait
+}
+
+// waitRateLimit blocks until both the global and the per-host limiter allow a request to host.
+func (c *Client) waitRateLimit(ctx context.Context, host string) error {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if limiter, ok := c.hostLimiters[host]; ok {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
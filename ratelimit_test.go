@@ -0,0 +1,113 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter driven by a clock the test advances by hand.
+func newTestLimiter(requestsPerSecond float64, burst int) (*RateLimiter, *time.Time) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(requestsPerSecond, burst)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	l, now := newTestLimiter(2, 3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if wait := l.WaitTime(); wait != 0 {
+			t.Fatalf("request %d: expected no wait within burst, got %v", i, wait)
+		}
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+
+	if wait := l.WaitTime(); wait != 500*time.Millisecond {
+		t.Errorf("Expected 500ms wait after burst, got %v", wait)
+	}
+
+	*now = now.Add(250 * time.Millisecond)
+	if wait := l.WaitTime(); wait != 250*time.Millisecond {
+		t.Errorf("Expected 250ms wait after partial refill, got %v", wait)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if wait := l.WaitTime(); wait != 0 {
+		t.Errorf("Expected no wait after refill, got %v", wait)
+	}
+	if l.tokens != l.burst {
+		t.Errorf("Expected tokens capped at burst, got %v", l.tokens)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l, _ := newTestLimiter(0.001, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if l.tokens < 0 {
+		t.Errorf("Expected cancelled reservation to be returned, tokens = %v", l.tokens)
+	}
+}
+
+func TestClientRateLimitSharedAcrossEndpoints(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteSummary":{"result":[{"price":{"symbol":"AAPL"}}]}}`))
+	})
+	host := mustParseURL(t, srv.URL).Host
+
+	// The burst covers the cookie and crumb bootstrap plus one data request.
+	c := newTestClient(srv, WithRateLimit(0.001, 3))
+	if _, err := newInformationWithClient(c).GetInfo("AAPL"); err != nil {
+		t.Fatalf("GetInfo returned error: %v", err)
+	}
+	if wait := c.RateLimitWait(host); wait <= time.Minute {
+		t.Errorf("Expected an exhausted limiter, got wait %v", wait)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := newSearchWithClient(c).GetSearchResultsContext(ctx, "AAPL", 5)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected search to be throttled by the shared limiter, got %v", err)
+	}
+}
+
+func TestClientHostRateLimit(t *testing.T) {
+	c := NewClient(WithHostRateLimit("example.com", 1, 1))
+	if wait := c.RateLimitWait("example.com"); wait != 0 {
+		t.Errorf("Expected no wait before any request, got %v", wait)
+	}
+	if err := c.waitRateLimit(context.Background(), "example.com"); err != nil {
+		t.Fatalf("waitRateLimit returned error: %v", err)
+	}
+	if wait := c.RateLimitWait("example.com"); wait <= 0 {
+		t.Errorf("Expected a wait for the limited host, got %v", wait)
+	}
+	if wait := c.RateLimitWait("other.com"); wait != 0 {
+		t.Errorf("Expected other hosts to be unaffected, got %v", wait)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", raw, err)
+	}
+	return u
+}
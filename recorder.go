@@ -0,0 +1,198 @@
+package yahoofinanceapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder talks to the network or replays a cassette.
+type RecorderMode int
+
+const (
+	// ModeReplay serves every request from the cassette and fails requests it has no recording for.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends every request to the network and records the interactions for Save.
+	ModeRecord
+	// ModeReplayOrRecord replays known requests and records the ones missing from the cassette.
+	ModeReplayOrRecord
+)
+
+// ErrNoRecording is returned by a replaying Recorder for requests missing from its cassette.
+var ErrNoRecording = errors.New("no recorded response for request")
+
+// Cassette is the on-disk format of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request by method and URL; ignored query parameters are stripped from the URL.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// RecordedResponse holds what is needed to rebuild a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records responses to a cassette file and replays them later,
+// so code using the Client can be tested without network access:
+//
+//	rec, err := yfa.NewRecorder("testdata/aapl.json", yfa.ModeReplay)
+//	client := yfa.NewClient(yfa.WithTransport(rec))
+type Recorder struct {
+	// Transport sends requests in record mode. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// IgnoreParams lists query parameters left out when matching requests, because they
+	// differ between runs. Defaults to DefaultIgnoredParams.
+	IgnoreParams []string
+
+	path string
+	mode RecorderMode
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// DefaultIgnoredParams are the query parameters that change between otherwise identical requests:
+// the session crumb and the "now" end of history queries.
+var DefaultIgnoredParams = []string{"crumb", "period2"}
+
+// NewRecorder creates a Recorder backed by the cassette at path.
+// In ModeReplay and ModeReplayOrRecord the cassette is loaded; it must exist for ModeReplay.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{
+		Transport:    http.DefaultTransport,
+		IgnoreParams: DefaultIgnoredParams,
+		path:         path,
+		mode:         mode,
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if mode == ModeReplayOrRecord && errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := RecordedRequest{Method: req.Method, URL: r.normalizeURL(req.URL)}
+
+	if r.mode != ModeRecord {
+		if resp, ok := r.replay(req, recorded); ok {
+			return resp, nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, recorded.Method, recorded.URL)
+		}
+	}
+
+	resp, err := r.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	// Session cookies are credentials; the replayed session works without them.
+	header.Del("Set-Cookie")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Request == recorded {
+			// Replay serves the first match only, so later duplicates would never be used.
+			return resp, nil
+		}
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: string(body)},
+	})
+	return resp, nil
+}
+
+// replay returns the first recorded response matching the request.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, true
+	}
+	return nil, false
+}
+
+// normalizeURL drops the ignored parameters and sorts the rest, so equivalent requests compare equal.
+func (r *Recorder) normalizeURL(u *url.URL) string {
+	query := u.Query()
+	for _, param := range r.IgnoreParams {
+		query.Del(param)
+	}
+	normalized := *u
+	normalized.RawQuery = query.Encode()
+	normalized.ForceQuery = false
+	normalized.Fragment = ""
+	return normalized.String()
+}
+
+// Save writes the recorded interactions to the cassette file, creating its directory if needed.
+// It is a no-op in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
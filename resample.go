@@ -0,0 +1,225 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type frequencyUnit int
+
+const (
+	unitMinute frequencyUnit = iota
+	unitDay
+	unitWeek
+)
+
+// Frequency is the bar length of a resampled Series. Create one with Minutes, Hours, Days or Weeks.
+type Frequency struct {
+	unit      frequencyUnit
+	n         int
+	weekStart time.Weekday
+}
+
+// Minutes returns a frequency of n-minute bars, e.g. Minutes(10).
+func Minutes(n int) Frequency {
+	return Frequency{unit: unitMinute, n: n}
+}
+
+// Hours returns a frequency of n-hour bars, e.g. Hours(4).
+func Hours(n int) Frequency {
+	return Frequency{unit: unitMinute, n: 60 * n}
+}
+
+// Days returns a frequency of bars spanning n trading days each.
+func Days(n int) Frequency {
+	return Frequency{unit: unitDay, n: n}
+}
+
+// Weeks returns a frequency of bars spanning n weeks that start on start, e.g. Weeks(1, time.Wednesday)
+// for weeks from Wednesday to Tuesday.
+func Weeks(n int, start time.Weekday) Frequency {
+	return Frequency{unit: unitWeek, n: n, weekStart: start}
+}
+
+// String returns the frequency in Yahoo's interval notation, e.g. "10m", "4h", "2d" or "1wk".
+// Weeks that do not start on Monday carry their first day, e.g. "1wk-WED".
+func (f Frequency) String() string {
+	switch {
+	case f.unit == unitMinute && f.n < 1:
+		return fmt.Sprintf("%dm", f.n)
+	case f.unit == unitMinute && f.n%60 == 0:
+		return fmt.Sprintf("%dh", f.n/60)
+	case f.unit == unitMinute:
+		return fmt.Sprintf("%dm", f.n)
+	case f.unit == unitDay:
+		return fmt.Sprintf("%dd", f.n)
+	case f.weekStart != time.Monday:
+		return fmt.Sprintf("%dwk-%s", f.n, strings.ToUpper(f.weekStart.String()[:3]))
+	default:
+		return fmt.Sprintf("%dwk", f.n)
+	}
+}
+
+// interval returns the Series interval of bars resampled to f. Anchored weeks are plain weeks
+// there, so that the series still formats as daily or longer.
+func (f Frequency) interval() Interval {
+	if f.unit == unitWeek {
+		return Interval(fmt.Sprintf("%dwk", f.n))
+	}
+	return Interval(f.String())
+}
+
+// sourceIntervals are the intervals SourceInterval picks from, longest first.
+var sourceIntervals = []Interval{Interval90m, Interval1h, Interval30m, Interval15m, Interval5m, Interval2m, Interval1m}
+
+// SourceInterval returns the longest interval Yahoo serves that bars of f can be built from:
+// the longest intraday interval f is a multiple of, or 1d for days and weeks.
+func (f Frequency) SourceInterval() Interval {
+	if f.unit != unitMinute {
+		return Interval1d
+	}
+	for _, interval := range sourceIntervals {
+		if d := intradayDurations[interval]; (time.Duration(f.n)*time.Minute)%d == 0 {
+			return interval
+		}
+	}
+	return Interval1m
+}
+
+// Resample aggregates the bars of s into bars of f: the first open, the highest high, the lowest
+// low, the last close and the summed volume, with the events of the bars they combine.
+//
+// Bars are grouped in s.Location. Intraday bars never combine across trading days or sessions and
+// start at the first bar of their day and session, usually the session open. Day bars span n
+// trading days counted from the first bar; week bars start at midnight on the week's first day.
+func (s Series) Resample(f Frequency) (Series, error) {
+	if f.n < 1 {
+		return Series{}, fmt.Errorf("invalid resampling frequency %s", f)
+	}
+	source, intraday := intradayDurations[s.Interval]
+	switch {
+	case f.unit == unitMinute && (!intraday || (time.Duration(f.n)*time.Minute)%source != 0):
+		return Series{}, fmt.Errorf("cannot resample %s bars into %s bars", s.Interval, f)
+	case f.unit != unitMinute && !intraday && s.Interval != Interval1d:
+		return Series{}, fmt.Errorf("cannot resample %s bars into %s bars", s.Interval, f)
+	}
+
+	resampled := s
+	resampled.Interval = f.interval()
+	resampled.Bars = nil
+	var keys bucketKeys
+	var current bucket
+	for i, bar := range s.Bars {
+		next := keys.next(f, bar)
+		if i > 0 && next.start.Equal(current.start) && next.session == current.session {
+			resampled.Bars[len(resampled.Bars)-1].merge(bar)
+			continue
+		}
+		current = next
+		bar.Time = next.start
+		resampled.Bars = append(resampled.Bars, bar)
+	}
+	return resampled, nil
+}
+
+// bucket identifies the resampled bar a source bar belongs to by the time the resampled bar starts
+// and, for intraday bars, the session.
+type bucket struct {
+	start   time.Time
+	session Session
+}
+
+// bucketKeys assigns consecutive bars to buckets, tracking the runs the buckets are counted from.
+type bucketKeys struct {
+	runDate    int64     // Day number of the current trading day
+	runSession Session   // Session of the current intraday run
+	runStart   time.Time // First bar of the current intraday run
+	days       int       // Trading days seen before the current one
+	dayStart   time.Time // Midnight of the first trading day of the current day bucket
+	firstWeek  int64     // Day number of the start of the first week
+}
+
+func (k *bucketKeys) next(f Frequency, bar Bar) bucket {
+	date := dayNumber(bar.Time)
+	newDay := k.runStart.IsZero() || date != k.runDate
+	switch f.unit {
+	case unitMinute:
+		if newDay || bar.Session != k.runSession {
+			k.runStart = bar.Time
+		}
+		k.runDate, k.runSession = date, bar.Session
+		length := time.Duration(f.n) * time.Minute
+		offset := bar.Time.Sub(k.runStart) / length * length
+		return bucket{start: k.runStart.Add(offset), session: bar.Session}
+	case unitDay:
+		if newDay {
+			if k.days%f.n == 0 {
+				k.dayStart = midnight(bar.Time)
+			}
+			k.days++
+		}
+		k.runDate, k.runStart = date, bar.Time
+		return bucket{start: k.dayStart}
+	default:
+		weekStart := date - int64((int(bar.Time.Weekday())-int(f.weekStart)+7)%7)
+		if k.runStart.IsZero() {
+			k.firstWeek = weekStart
+		}
+		k.runDate, k.runStart = date, bar.Time
+		weeks := (weekStart - k.firstWeek) / 7 / int64(f.n) * int64(f.n)
+		start := midnight(bar.Time).AddDate(0, 0, int(k.firstWeek+7*weeks-date))
+		return bucket{start: start}
+	}
+}
+
+// merge adds the next bar of the same bucket to b. Missing prices are skipped.
+func (b *Bar) merge(next Bar) {
+	if math.IsNaN(b.Open) {
+		b.Open = next.Open
+	}
+	if math.IsNaN(b.High) || next.High > b.High {
+		b.High = next.High
+	}
+	if math.IsNaN(b.Low) || next.Low < b.Low {
+		b.Low = next.Low
+	}
+	if !math.IsNaN(next.Close) {
+		b.Close = next.Close
+		b.AdjClose = next.AdjClose
+	}
+	b.Volume += next.Volume
+	b.addEvents(next)
+}
+
+// dayNumber returns the number of days from 1970-01-01 to the date of t in its location.
+func dayNumber(t time.Time) int64 {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+}
+
+// midnight returns the start of the day of t in its location.
+func midnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// ResampledHistory retrieves the history of query and resamples it into bars of f. Unless the query
+// sets an Interval, it requests the SourceInterval of f.
+func (t *Ticker) ResampledHistory(query HistoryQuery, f Frequency) (Series, error) {
+	return t.ResampledHistoryContext(context.Background(), query, f)
+}
+
+// ResampledHistoryContext is like ResampledHistory but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) ResampledHistoryContext(ctx context.Context, query HistoryQuery, f Frequency) (Series, error) {
+	if query.Interval == "" {
+		query.Interval = f.SourceInterval()
+	}
+	series, err := t.HistorySeriesContext(ctx, query)
+	if err != nil {
+		return Series{}, err
+	}
+	return series.Resample(f)
+}
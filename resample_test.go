@@ -0,0 +1,150 @@
+package yahoofinanceapi
+
+import (
+	"testing"
+	"time"
+)
+
+// testBars returns n bars from start spaced by step, with open i, high i+1, low i-1, close i+0.5
+// and volume 100 for the i-th bar, counting from 1.
+func testBars(start time.Time, step time.Duration, n int, session Session) []Bar {
+	bars := make([]Bar, n)
+	for i := range bars {
+		v := float64(i + 1)
+		bars[i] = Bar{
+			Time:      start.Add(time.Duration(i) * step),
+			PriceData: PriceData{Open: v, High: v + 1, Low: v - 1, Close: v + 0.5, AdjClose: v + 0.5, Volume: 100},
+			Session:   session,
+		}
+	}
+	return bars
+}
+
+func TestSeriesResampleIntraday(t *testing.T) {
+	ny := time.FixedZone("EST", -5*60*60)
+	open := time.Date(2025, 1, 6, 9, 30, 0, 0, ny)
+	hourly := Series{Interval: Interval1h, Location: ny, Bars: testBars(open, time.Hour, 7, SessionRegular)}
+
+	s, err := hourly.Resample(Hours(4))
+	if err != nil {
+		t.Fatalf("Resample returned error: %v", err)
+	}
+	if s.Interval != "4h" || s.Len() != 2 {
+		t.Fatalf("Expected two 4h bars, got %d %s bars", s.Len(), s.Interval)
+	}
+	want := Bar{Time: open, PriceData: PriceData{Open: 1, High: 5, Low: 0, Close: 4.5, AdjClose: 4.5, Volume: 400}, Session: SessionRegular}
+	if s.Bars[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, s.Bars[0])
+	}
+	if !s.Bars[1].Time.Equal(open.Add(4*time.Hour)) || s.Bars[1].Volume != 300 {
+		t.Errorf("Expected the second bar at 13:30 with 3 bars of volume, got %+v", s.Bars[1])
+	}
+
+	// Pre-market bars and the next day start buckets of their own.
+	bars := testBars(open.Add(-10*time.Minute), 5*time.Minute, 2, SessionPre)
+	bars = append(bars, testBars(open, 5*time.Minute, 4, SessionRegular)...)
+	bars = append(bars, testBars(open.AddDate(0, 0, 1), 5*time.Minute, 3, SessionRegular)...)
+	fiveMinutes := Series{Interval: Interval5m, Location: ny, Bars: bars}
+	s, err = fiveMinutes.Resample(Minutes(10))
+	if err != nil {
+		t.Fatalf("Resample returned error: %v", err)
+	}
+	wantTimes := []time.Time{open.Add(-10 * time.Minute), open, open.Add(10 * time.Minute), open.AddDate(0, 0, 1), open.AddDate(0, 0, 1).Add(10 * time.Minute)}
+	if s.Len() != len(wantTimes) {
+		t.Fatalf("Expected %d bars, got %d: %+v", len(wantTimes), s.Len(), s.Bars)
+	}
+	for i, want := range wantTimes {
+		if !s.Bars[i].Time.Equal(want) {
+			t.Errorf("Bar %d: expected %v, got %v", i, want, s.Bars[i].Time)
+		}
+	}
+	if s.Bars[0].Session != SessionPre || s.Bars[1].Session != SessionRegular {
+		t.Errorf("Expected the sessions to be kept, got %q and %q", s.Bars[0].Session, s.Bars[1].Session)
+	}
+	if !hourly.Bars[1].Time.Equal(open.Add(time.Hour)) || hourly.Bars[0].Volume != 100 {
+		t.Error("Resample modified the original series")
+	}
+}
+
+func TestSeriesResampleDaily(t *testing.T) {
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	var bars []Bar
+	for _, day := range []int{0, 1, 2, 3, 4, 7, 8, 9, 10, 11} {
+		bar := testBars(monday.AddDate(0, 0, day), 0, 1, "")[0]
+		bar.Close = float64(day)
+		bars = append(bars, bar)
+	}
+	bars[3].Dividend = 0.25
+	daily := Series{Interval: Interval1d, Location: time.UTC, Bars: bars}
+
+	twoDays, err := daily.Resample(Days(2))
+	if err != nil {
+		t.Fatalf("Resample returned error: %v", err)
+	}
+	if twoDays.Len() != 5 || !twoDays.Bars[2].Time.Equal(monday.AddDate(0, 0, 4)) || twoDays.Bars[1].Dividend != 0.25 {
+		t.Errorf("Expected 5 bars of 2 trading days each, got %+v", twoDays.Bars)
+	}
+
+	weeks, err := daily.Resample(Weeks(1, time.Wednesday))
+	if err != nil {
+		t.Fatalf("Resample returned error: %v", err)
+	}
+	wantStarts := []time.Time{monday.AddDate(0, 0, -5), monday.AddDate(0, 0, 2), monday.AddDate(0, 0, 9)}
+	wantCloses := []float64{1, 8, 11}
+	if weeks.Len() != 3 || weeks.Interval != "1wk" {
+		t.Fatalf("Expected 3 weekly bars, got %d %s bars", weeks.Len(), weeks.Interval)
+	}
+	for i := range wantStarts {
+		if !weeks.Bars[i].Time.Equal(wantStarts[i]) || weeks.Bars[i].Close != wantCloses[i] {
+			t.Errorf("Week %d: expected start %v and close %v, got %v and %v", i, wantStarts[i], wantCloses[i], weeks.Bars[i].Time, weeks.Bars[i].Close)
+		}
+	}
+	if _, ok := weeks.Map()["2025-01-08"]; !ok {
+		t.Errorf("Expected weekly bars keyed by date, got %v", weeks.Map())
+	}
+}
+
+func TestSeriesResampleInvalid(t *testing.T) {
+	tests := []struct {
+		interval Interval
+		f        Frequency
+	}{
+		{Interval1d, Hours(4)},
+		{Interval5m, Minutes(7)},
+		{Interval1wk, Days(2)},
+		{Interval1d, Days(0)},
+	}
+	for _, tt := range tests {
+		if _, err := (Series{Interval: tt.interval}).Resample(tt.f); err == nil {
+			t.Errorf("Expected an error resampling %s bars into %s", tt.interval, tt.f)
+		}
+	}
+	if _, err := (Series{Interval: Interval1m}).Resample(Minutes(0)); err == nil || err.Error() != "invalid resampling frequency 0m" {
+		t.Errorf("Expected an invalid 0m frequency, got %v", err)
+	}
+}
+
+func TestFrequencySourceInterval(t *testing.T) {
+	tests := []struct {
+		f    Frequency
+		name string
+		want Interval
+	}{
+		{Minutes(10), "10m", Interval5m},
+		{Minutes(45), "45m", Interval15m},
+		{Minutes(7), "7m", Interval1m},
+		{Minutes(90), "90m", Interval90m},
+		{Hours(4), "4h", Interval1h},
+		{Days(2), "2d", Interval1d},
+		{Weeks(1, time.Monday), "1wk", Interval1d},
+		{Weeks(2, time.Friday), "2wk-FRI", Interval1d},
+	}
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.name {
+			t.Errorf("Expected %s, got %s", tt.name, got)
+		}
+		if got := tt.f.SourceInterval(); got != tt.want {
+			t.Errorf("%s: expected source interval %s, got %s", tt.f, tt.want, got)
+		}
+	}
+}
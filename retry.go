@@ -0,0 +1,101 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the Client retries requests that Yahoo Finance rejects with
+// 401 (invalid crumb), 429 (rate limited) or a 5xx status, or that fail in transit.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first one; 1 disables retries
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each further attempt
+	MaxDelay    time.Duration // Upper bound of a single delay, also applied to Retry-After
+}
+
+// DefaultRetryPolicy returns the policy used by clients created without WithRetryPolicy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// WithRetryPolicy replaces the Client's retry policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// shouldRetry reports whether a response with the given status code is worth another attempt.
+func shouldRetry(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+// It uses exponential backoff with jitter, unless the response carries a Retry-After header.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return p.capDelay(delay)
+		}
+	}
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 {
+		delay = p.MaxDelay
+	}
+	delay = p.capDelay(delay)
+	// Full jitter over the upper half keeps concurrent callers from retrying in lockstep.
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (p RetryPolicy) capDelay(delay time.Duration) time.Duration {
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: delay-seconds and an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until ctx is done, whichever happens first.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package yahoofinanceapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestRetryOnServerError(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+
+	c := newTestClient(srv, WithRetryPolicy(fastRetry))
+	resp, err := c.Get(srv.URL+"/v8/finance/chart/AAPL", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("Expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	c := newTestClient(srv, WithRetryPolicy(fastRetry))
+	resp, err := c.Get(srv.URL+"/v8/finance/chart/AAPL", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", resp.StatusCode)
+	}
+	if n := hits.Load(); n != int32(fastRetry.MaxAttempts) {
+		t.Errorf("Expected %d attempts, got %d", fastRetry.MaxAttempts, n)
+	}
+}
+
+func TestRetryDoesNotRetryClientErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	c := newTestClient(srv, WithRetryPolicy(fastRetry))
+	resp, err := c.Get(srv.URL+"/v8/finance/chart/AAPL", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected 1 attempt, got %d", n)
+	}
+}
+
+func TestRetryRefreshesCrumbOnUnauthorized(t *testing.T) {
+	var crumbs atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cookie", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "A3", Value: "session"})
+	})
+	mux.HandleFunc("/v1/test/getcrumb", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "crumb-%d", crumbs.Add(1))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("crumb") != "crumb-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"finance":{"result":null,"error":{"code":"Unauthorized","description":"Invalid Crumb"}}}`))
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newTestClient(srv, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour}))
+	resp, err := c.Get(srv.URL+"/v8/finance/chart/AAPL", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 after crumb refresh, got %d", resp.StatusCode)
+	}
+	if c.crumb != "crumb-2" {
+		t.Errorf("Expected refreshed crumb 'crumb-2', got %q", c.crumb)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for retry, upper := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: 300 * time.Millisecond} {
+		delay := p.backoff(retry, nil)
+		if delay < upper/2 || delay > upper {
+			t.Errorf("retry %d: expected delay in [%v, %v], got %v", retry, upper/2, upper, delay)
+		}
+	}
+}
+
+func TestBackoffHonoursRetryAfter(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if delay := p.backoff(1, resp); delay != 7*time.Second {
+		t.Errorf("Expected 7s from Retry-After, got %v", delay)
+	}
+
+	p.MaxDelay = 2 * time.Second
+	if delay := p.backoff(1, resp); delay != 2*time.Second {
+		t.Errorf("Expected Retry-After capped at 2s, got %v", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("Expected empty Retry-After to be ignored")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("Expected malformed Retry-After to be ignored")
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 || delay > time.Hour {
+		t.Errorf("Expected HTTP-date Retry-After within an hour, got %v (ok=%v)", delay, ok)
+	}
+}
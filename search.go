@@ -1,10 +1,10 @@
 package yahoofinanceapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log/slog"
 	"net/url"
 	"strconv"
 	"strings"
@@ -103,11 +103,8 @@ func DefaultSearchParams(query string, quotesCount int) SearchParams {
 	}
 }
 
-// buildSearchURL constructs the Yahoo Finance search URL with parameters
-func buildSearchURL(params SearchParams) string {
-	baseURL := fmt.Sprintf("%s/v1/finance/search", BASE_URL)
-
-	// Build query parameters
+// buildSearchValues constructs the query parameters of a Yahoo Finance search request
+func buildSearchValues(params SearchParams) url.Values {
 	values := url.Values{}
 	values.Set("q", params.Query)
 	values.Set("lang", params.Lang)
@@ -131,7 +128,7 @@ func buildSearchURL(params SearchParams) string {
 		values.Set("newsQueryId", params.NewsQueryId)
 	}
 
-	return fmt.Sprintf("%s?%s", baseURL, values.Encode())
+	return values
 }
 
 // Search holds the HTTP client for symbol searching
@@ -139,9 +136,14 @@ type Search struct {
 	client *Client
 }
 
-// newSearch initializes the Search struct with an HTTP client
+// newSearch initializes the Search struct with the shared HTTP client
 func newSearch() *Search {
-	return &Search{client: getClient()}
+	return newSearchWithClient(getClient())
+}
+
+// newSearchWithClient initializes the Search struct with the given client
+func newSearchWithClient(client *Client) *Search {
+	return &Search{client: client}
 }
 
 // GetSearchResults searches for investment symbols by query using Yahoo Finance's public search API
@@ -158,8 +160,13 @@ func newSearch() *Search {
 //   - YahooSearchResponse: Raw search response from Yahoo Finance
 //   - error: Error if request fails or query is invalid
 func (s *Search) GetSearchResults(query string, limit int) (YahooSearchResponse, error) {
+	return s.GetSearchResultsContext(context.Background(), query, limit)
+}
+
+// GetSearchResultsContext is like GetSearchResults but aborts the request when ctx is cancelled
+func (s *Search) GetSearchResultsContext(ctx context.Context, query string, limit int) (YahooSearchResponse, error) {
 	params := DefaultSearchParams(query, limit)
-	return s.GetSearchResultsWithOptions(params)
+	return s.GetSearchResultsWithOptionsContext(ctx, params)
 }
 
 // GetSearchResultsWithOptions searches for investment symbols using custom parameters
@@ -171,6 +178,11 @@ func (s *Search) GetSearchResults(query string, limit int) (YahooSearchResponse,
 //   - YahooSearchResponse: Raw search response from Yahoo Finance
 //   - error: Error if request fails or query is invalid
 func (s *Search) GetSearchResultsWithOptions(params SearchParams) (YahooSearchResponse, error) {
+	return s.GetSearchResultsWithOptionsContext(context.Background(), params)
+}
+
+// GetSearchResultsWithOptionsContext is like GetSearchResultsWithOptions but aborts the request when ctx is cancelled
+func (s *Search) GetSearchResultsWithOptionsContext(ctx context.Context, params SearchParams) (YahooSearchResponse, error) {
 	// Validate query
 	params.Query = strings.TrimSpace(params.Query)
 	if params.Query == "" {
@@ -185,32 +197,32 @@ func (s *Search) GetSearchResultsWithOptions(params SearchParams) (YahooSearchRe
 		params.QuotesCount = 20
 	}
 
-	// Build request URL
-	searchURL := buildSearchURL(params)
+	// Build request URL and parameters
+	endpoint := fmt.Sprintf("%s/v1/finance/search", s.client.baseURL)
 
 	// Make the HTTP GET request using the client
-	resp, err := s.client.Get(searchURL, url.Values{})
+	resp, err := s.client.GetContext(ctx, endpoint, buildSearchValues(params))
 	if err != nil {
-		slog.Error("Failed to search symbols", "err", err)
+		s.client.logger.Error("Failed to search symbols", "query", params.Query, "err", err)
 		return YahooSearchResponse{}, fmt.Errorf("failed to search symbols: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check status code
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return YahooSearchResponse{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return YahooSearchResponse{}, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	// Check status code
+	if !isSuccess(resp.StatusCode) {
+		return YahooSearchResponse{}, statusError(resp.StatusCode, body)
+	}
+
 	// Parse JSON response
 	var searchResponse YahooSearchResponse
 	if err := json.Unmarshal(body, &searchResponse); err != nil {
-		return YahooSearchResponse{}, fmt.Errorf("failed to parse JSON response: %w", err)
+		return YahooSearchResponse{}, fmt.Errorf("failed to parse JSON response (body %q): %w", bodySnippet(body), err)
 	}
 
 	return searchResponse, nil
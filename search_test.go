@@ -6,12 +6,7 @@ import (
 )
 
 func TestGetSearchResults_BasicSearch(t *testing.T) {
-	// Skip in unit tests (requires network)
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	results, err := s.GetSearchResults("AAPL", 10)
 
 	if err != nil {
@@ -51,11 +46,7 @@ func TestGetSearchResults_EmptyQuery(t *testing.T) {
 }
 
 func TestGetSearchResults_VietnameseStock(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	results, err := s.GetSearchResults("VCB", 10)
 
 	if err != nil {
@@ -68,11 +59,7 @@ func TestGetSearchResults_VietnameseStock(t *testing.T) {
 }
 
 func TestGetSearchResults_Cryptocurrency(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	results, err := s.GetSearchResults("Bitcoin", 10)
 
 	if err != nil {
@@ -99,11 +86,7 @@ func TestGetSearchResults_Cryptocurrency(t *testing.T) {
 }
 
 func TestGetSearchResults_Limit(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	limit := 5
 	results, err := s.GetSearchResults("AAPL", limit)
 
@@ -117,7 +100,7 @@ func TestGetSearchResults_Limit(t *testing.T) {
 }
 
 func TestGetSearchResults_MaxLimitEnforcement(t *testing.T) {
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	params := DefaultSearchParams("AAPL", 50) // Try to set limit above 20
 
 	results, err := s.GetSearchResultsWithOptions(params)
@@ -133,7 +116,7 @@ func TestGetSearchResults_MaxLimitEnforcement(t *testing.T) {
 }
 
 func TestGetSearchResults_DefaultLimit(t *testing.T) {
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	params := DefaultSearchParams("AAPL", 0) // Set invalid limit
 
 	results, err := s.GetSearchResultsWithOptions(params)
@@ -149,11 +132,7 @@ func TestGetSearchResults_DefaultLimit(t *testing.T) {
 }
 
 func TestGetSearchResults_CompanyName(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	results, err := s.GetSearchResults("Apple", 10)
 
 	if err != nil {
@@ -208,7 +187,7 @@ func TestDefaultSearchParams(t *testing.T) {
 	}
 }
 
-func TestBuildSearchURL(t *testing.T) {
+func TestBuildSearchValues(t *testing.T) {
 	params := SearchParams{
 		Query:        "AAPL",
 		QuotesCount:  5,
@@ -218,7 +197,7 @@ func TestBuildSearchURL(t *testing.T) {
 		Lang:         "en-US",
 	}
 
-	url := buildSearchURL(params)
+	url := buildSearchValues(params).Encode()
 
 	if !strings.Contains(url, "q=AAPL") {
 		t.Error("expected URL to contain query parameter")
@@ -280,11 +259,7 @@ func TestTransformSearchData(t *testing.T) {
 }
 
 func TestGetSearchResults_ETF(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	results, err := s.GetSearchResults("SPY", 10)
 
 	if err != nil {
@@ -311,11 +286,7 @@ func TestGetSearchResults_ETF(t *testing.T) {
 }
 
 func TestGetSearchResults_CustomParams(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	params := DefaultSearchParams("AAPL", 5)
 	params.EnableFuzzyQuery = true
 	params.Lang = "vi-VN"
@@ -332,7 +303,7 @@ func TestGetSearchResults_CustomParams(t *testing.T) {
 }
 
 func TestGetSearchResults_TrailingWhitespaceQuery(t *testing.T) {
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	params := DefaultSearchParams("  AAPL  ", 5)
 
 	results, err := s.GetSearchResultsWithOptions(params)
@@ -348,11 +319,7 @@ func TestGetSearchResults_TrailingWhitespaceQuery(t *testing.T) {
 }
 
 func TestGetSearchResults_InvalidSearch(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	// Use a very unlikely search term
 	results, err := s.GetSearchResults("xyz123abc456def789", 10)
 
@@ -367,11 +334,7 @@ func TestGetSearchResults_InvalidSearch(t *testing.T) {
 }
 
 func TestGetSearchResults_MultipleExchanges(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	s := newSearch()
+	s := newSearchWithClient(newReplayClient(t, "search"))
 	results, err := s.GetSearchResults("Toyota", 10)
 
 	if err != nil {
@@ -396,11 +359,7 @@ func TestGetSearchResults_MultipleExchanges(t *testing.T) {
 }
 
 func TestTicker_Search(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	ticker := NewTicker("AAPL")
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "search"))
 	results, err := ticker.Search("Bitcoin", 10)
 
 	if err != nil {
@@ -413,11 +372,7 @@ func TestTicker_Search(t *testing.T) {
 }
 
 func TestTicker_SearchWithOptions(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	ticker := NewTicker("AAPL")
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "search"))
 	params := DefaultSearchParams("VCB", 5)
 	params.EnableFuzzyQuery = true
 
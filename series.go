@@ -0,0 +1,262 @@
+package yahoofinanceapi
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Bar is the price data of one interval, starting at Time, with the corporate actions
+// whose ex-date falls into the interval.
+type Bar struct {
+	Time time.Time
+	PriceData
+	Dividend    float64 // Dividends per share, 0 if none
+	SplitFactor float64 // New shares per old share, e.g. 4 for a 4:1 split; 0 if none
+	CapitalGain float64 // Capital gain distributions per share, 0 if none
+	Session     Session // Trading session of an intraday bar; empty for daily or longer intervals
+}
+
+// Series is a price history in chronological order, along with the corporate actions of its period.
+// Times are in the exchange's timezone, or in UTC with HistoryQuery.UTC. Daily or longer bars start
+// at midnight of their trading date, so Time.Format("2006-01-02") is the exchange's trading date.
+type Series struct {
+	Symbol       string
+	Interval     Interval       // The bar interval reported by Yahoo, e.g. "1d" or "1m"
+	Location     *time.Location // The timezone of the bar and event times
+	Bars         []Bar
+	Dividends    []Dividend
+	Splits       []Split
+	CapitalGains []CapitalGain
+	Missing      MissingReport // The bars that had missing prices, per HistoryQuery.Missing
+}
+
+// newSeries builds a Series from a chart result. The interval reported by Yahoo takes precedence over the requested one.
+// Missing prices are set to NaN and missing volumes to 0; fillMissing applies a MissingPolicy to them.
+func newSeries(result YahooHistoryResult, interval Interval) Series {
+	if result.Meta.DataGranularity != "" {
+		interval = Interval(result.Meta.DataGranularity)
+	}
+	loc := exchangeLocation(result.Meta)
+	s := Series{
+		Symbol:       result.Meta.Symbol,
+		Interval:     interval,
+		Location:     loc,
+		Dividends:    result.Events.dividends(loc),
+		Splits:       result.Events.splits(loc),
+		CapitalGains: result.Events.capitalGains(loc),
+	}
+	if len(result.Indicators.Quote) == 0 {
+		return s
+	}
+	quote := result.Indicators.Quote[0]
+	var adjClose []*float64
+	if len(result.Indicators.AdjClose) > 0 {
+		adjClose = result.Indicators.AdjClose[0].AdjClose
+	}
+	var sessions *tradingSessions
+	if !isDailyOrLonger(interval) {
+		ts := newTradingSessions(result.Meta)
+		sessions = &ts
+	}
+	daily := isDailyOrLonger(interval)
+	s.Bars = make([]Bar, 0, len(result.Timestamp))
+	for i, timestamp := range result.Timestamp {
+		bar := Bar{
+			Time: barTime(time.Unix(timestamp, 0).In(loc), loc, daily),
+			PriceData: PriceData{
+				Open:   floatAt(quote.Open, i),
+				High:   floatAt(quote.High, i),
+				Low:    floatAt(quote.Low, i),
+				Close:  floatAt(quote.Close, i),
+				Volume: intAt(quote.Volume, i),
+			},
+		}
+		bar.AdjClose = bar.Close
+		if adj := floatAt(adjClose, i); !math.IsNaN(adj) {
+			bar.AdjClose = adj
+		}
+		if sessions != nil {
+			bar.Session = sessions.at(timestamp)
+		}
+		s.Bars = append(s.Bars, bar)
+	}
+	// Yahoo returns bars in order; sorting guards against the rare out-of-order timestamp.
+	sort.SliceStable(s.Bars, func(i, j int) bool { return s.Bars[i].Time.Before(s.Bars[j].Time) })
+	s.attachEvents()
+	return s
+}
+
+// applyQuery applies the options of query that shape a fetched series.
+func (s *Series) applyQuery(query HistoryQuery) {
+	s.fillMissing(query.Missing)
+	s.adjust(query)
+	if query.UTC {
+		*s = s.In(time.UTC)
+	}
+}
+
+// adjust applies the AutoAdjust and AdjustVolume options of query to the bars.
+func (s *Series) adjust(query HistoryQuery) {
+	if query.AutoAdjust {
+		for i := range s.Bars {
+			bar := &s.Bars[i]
+			if bar.Close == 0 {
+				continue
+			}
+			ratio := bar.AdjClose / bar.Close
+			bar.Open *= ratio
+			bar.High *= ratio
+			bar.Low *= ratio
+			bar.Close = bar.AdjClose
+		}
+	}
+	if query.AdjustVolume {
+		// Walk backwards, accumulating the factors of the splits after each bar.
+		factor := 1.0
+		for i := len(s.Bars) - 1; i >= 0; i-- {
+			bar := &s.Bars[i]
+			bar.Volume = int64(math.Round(float64(bar.Volume) * factor))
+			if bar.SplitFactor > 0 {
+				factor *= bar.SplitFactor
+			}
+		}
+	}
+}
+
+// In returns the series with its times in loc. Daily or longer bars keep their trading date and
+// start at midnight in loc. The returned Series does not share its bars with s.
+func (s Series) In(loc *time.Location) Series {
+	daily := isDailyOrLonger(s.Interval)
+	bars := make([]Bar, len(s.Bars))
+	for i, bar := range s.Bars {
+		bar.Time = barTime(bar.Time, loc, daily)
+		bars[i] = bar
+	}
+	s.Bars = bars
+	s.Location = loc
+	s.Dividends = eventsIn(s.Dividends, func(d *Dividend) *time.Time { return &d.Date }, loc)
+	s.Splits = eventsIn(s.Splits, func(split *Split) *time.Time { return &split.Date }, loc)
+	s.CapitalGains = eventsIn(s.CapitalGains, func(g *CapitalGain) *time.Time { return &g.Date }, loc)
+	return s
+}
+
+// eventsIn returns a copy of events with their dates in loc.
+func eventsIn[E any](events []E, date func(*E) *time.Time, loc *time.Location) []E {
+	if events == nil {
+		return nil
+	}
+	located := make([]E, len(events))
+	for i := range events {
+		located[i] = events[i]
+		d := date(&located[i])
+		*d = d.In(loc)
+	}
+	return located
+}
+
+// barTime returns t in loc, or for daily or longer bars, midnight in loc of the date t has in its
+// own location.
+func barTime(t time.Time, loc *time.Location, daily bool) time.Time {
+	if !daily {
+		return t.In(loc)
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// locations caches the exchange timezones loaded by exchangeLocation.
+var locations sync.Map
+
+// exchangeLocation returns the timezone of the exchange of a chart. It falls back to the fixed UTC
+// offset Yahoo reports if the timezone database does not know the exchange's timezone.
+func exchangeLocation(meta YahooMeta) *time.Location {
+	if name := meta.ExchangeTimezoneName; name != "" {
+		if loc, ok := locations.Load(name); ok {
+			return loc.(*time.Location)
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			locations.Store(name, loc)
+			return loc
+		}
+	}
+	if meta.Timezone == "" && meta.GmtOffset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone(meta.Timezone, meta.GmtOffset)
+}
+
+// Len returns the number of bars.
+func (s Series) Len() int {
+	return len(s.Bars)
+}
+
+// Latest returns the most recent bar, or false if the series is empty.
+func (s Series) Latest() (Bar, bool) {
+	if len(s.Bars) == 0 {
+		return Bar{}, false
+	}
+	return s.Bars[len(s.Bars)-1], true
+}
+
+// At returns the bar starting exactly at t, or false if there is none.
+func (s Series) At(t time.Time) (Bar, bool) {
+	i := s.search(t)
+	if i < len(s.Bars) && s.Bars[i].Time.Equal(t) {
+		return s.Bars[i], true
+	}
+	return Bar{}, false
+}
+
+// Between returns the bars starting in [start, end). A zero start or end leaves that side open.
+// The returned Series shares its bars with s.
+func (s Series) Between(start, end time.Time) Series {
+	from, to := 0, len(s.Bars)
+	if !start.IsZero() {
+		from = s.search(start)
+	}
+	if !end.IsZero() {
+		to = s.search(end)
+	}
+	if to < from {
+		to = from
+	}
+	s.Bars = s.Bars[from:to:to]
+	s.Dividends = eventsBetween(s.Dividends, func(d Dividend) time.Time { return d.Date }, start, end)
+	s.Splits = eventsBetween(s.Splits, func(split Split) time.Time { return split.Date }, start, end)
+	s.CapitalGains = eventsBetween(s.CapitalGains, func(g CapitalGain) time.Time { return g.Date }, start, end)
+	return s
+}
+
+// eventsBetween returns the events dated in [start, end); a zero start or end leaves that side open.
+func eventsBetween[E any](events []E, date func(E) time.Time, start, end time.Time) []E {
+	var selected []E
+	for _, e := range events {
+		t := date(e)
+		if (start.IsZero() || !t.Before(start)) && (end.IsZero() || t.Before(end)) {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// search returns the index of the first bar starting at or after t.
+func (s Series) search(t time.Time) int {
+	return sort.Search(len(s.Bars), func(i int) bool { return !s.Bars[i].Time.Before(t) })
+}
+
+// Map converts the series to the map returned by Ticker.History, keyed by trading date for daily or
+// longer intervals and by date and time in s.Location otherwise. Bars whose keys collide, e.g. intraday bars in the
+// repeated hour when daylight saving time ends, keep only the last one.
+func (s Series) Map() map[string]PriceData {
+	layout := "2006-01-02 15:04:05"
+	if isDailyOrLonger(s.Interval) {
+		layout = "2006-01-02"
+	}
+	m := make(map[string]PriceData, len(s.Bars))
+	for _, bar := range s.Bars {
+		m[bar.Time.Format(layout)] = bar.PriceData
+	}
+	return m
+}
@@ -0,0 +1,242 @@
+package yahoofinanceapi
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// nullFloats returns values as a nullable chart array; NaN becomes null.
+func nullFloats(values ...float64) []*float64 {
+	nullable := make([]*float64, len(values))
+	for i := range values {
+		if !math.IsNaN(values[i]) {
+			nullable[i] = &values[i]
+		}
+	}
+	return nullable
+}
+
+// nullInts returns values as a nullable chart array; negative values become null.
+func nullInts(values ...int64) []*int64 {
+	nullable := make([]*int64, len(values))
+	for i := range values {
+		if values[i] >= 0 {
+			nullable[i] = &values[i]
+		}
+	}
+	return nullable
+}
+
+func testSeriesResult() YahooHistoryResult {
+	// Out of order on purpose; newSeries sorts the bars.
+	return YahooHistoryResult{
+		Meta:      YahooMeta{Symbol: "AAPL", DataGranularity: "1d"},
+		Timestamp: []int64{1704205800, 1704378600, 1704292200},
+		Indicators: YahooIndicator{Quote: []YahooQuote{{
+			Open:   nullFloats(187.15, 182.15, 184.22),
+			High:   nullFloats(188.44, 183.09, 185.88),
+			Low:    nullFloats(183.89, 180.88, 183.43),
+			Close:  nullFloats(185.64, 181.91, 184.25),
+			Volume: nullInts(82488700, 71983600, 58414500),
+		}}},
+	}
+}
+
+func TestNewSeries(t *testing.T) {
+	s := newSeries(testSeriesResult(), "1wk")
+	if s.Symbol != "AAPL" || s.Interval != "1d" {
+		t.Errorf("Expected AAPL with Yahoo's 1d interval, got %s and %s", s.Symbol, s.Interval)
+	}
+	if s.Len() != 3 {
+		t.Fatalf("Expected 3 bars, got %d", s.Len())
+	}
+	for i := 1; i < s.Len(); i++ {
+		if !s.Bars[i-1].Time.Before(s.Bars[i].Time) {
+			t.Errorf("Bars are not in chronological order: %v before %v", s.Bars[i-1].Time, s.Bars[i].Time)
+		}
+	}
+	latest, ok := s.Latest()
+	if !ok || latest.Close != 181.91 {
+		t.Errorf("Expected the latest close 181.91, got %v (ok=%v)", latest.Close, ok)
+	}
+}
+
+func TestSeriesAtAndBetween(t *testing.T) {
+	s := newSeries(testSeriesResult(), "")
+	second := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC) // Daily bars start at midnight
+
+	bar, ok := s.At(second)
+	if !ok || bar.Close != 184.25 {
+		t.Errorf("Expected bar with close 184.25, got %+v (ok=%v)", bar, ok)
+	}
+	if _, ok := s.At(second.Add(time.Minute)); ok {
+		t.Error("Expected no bar at a time between bars")
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       int
+	}{
+		{"all", time.Time{}, time.Time{}, 3},
+		{"from second", second, time.Time{}, 2},
+		{"until second", time.Time{}, second, 1},
+		{"second only", second, second.Add(time.Hour), 1},
+		{"reversed", second, second.Add(-time.Hour), 0},
+	}
+	for _, tt := range tests {
+		if got := s.Between(tt.start, tt.end).Len(); got != tt.want {
+			t.Errorf("%s: expected %d bars, got %d", tt.name, tt.want, got)
+		}
+	}
+
+	// Appending to a slice must not overwrite the bars of the original series.
+	head := s.Between(time.Time{}, second)
+	head.Bars = append(head.Bars, Bar{})
+	if s.Bars[1].Close != 184.25 {
+		t.Error("Appending to a sub-series modified the original")
+	}
+}
+
+func TestSeriesMap(t *testing.T) {
+	s := newSeries(testSeriesResult(), "")
+	m := s.Map()
+	if len(m) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(m))
+	}
+	if m["2024-01-02"].Close != 185.64 {
+		t.Errorf("Expected close 185.64 at 2024-01-02, got %v", m["2024-01-02"].Close)
+	}
+
+	result := testSeriesResult()
+	result.Meta.DataGranularity = "1h"
+	if _, ok := newSeries(result, "").Map()["2024-01-02 14:30:00"]; !ok {
+		t.Error("Expected intraday key 2024-01-02 14:30:00")
+	}
+}
+
+func TestHistorySeries(t *testing.T) {
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
+	series, err := ticker.HistorySeries(HistoryQuery{Range: "1mo", Interval: "1d"})
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if series.Len() == 0 {
+		t.Fatal("HistorySeries returned no bars")
+	}
+	quote, err := ticker.Quote()
+	if err != nil {
+		t.Fatalf("Quote returned error: %v", err)
+	}
+	latest, _ := series.Latest()
+	if quote != latest.PriceData {
+		t.Errorf("Expected Quote to return the latest bar %+v, got %+v", latest.PriceData, quote)
+	}
+}
+
+func TestSeriesAdjust(t *testing.T) {
+	result := testSeriesResult()
+	result.Timestamp = []int64{1704205800, 1704292200, 1704378600}
+	result.Indicators.Quote[0] = YahooQuote{
+		Open:   nullFloats(200, 100, 50),
+		High:   nullFloats(220, 110, 55),
+		Low:    nullFloats(180, 90, 45),
+		Close:  nullFloats(200, 100, 50),
+		Volume: nullInts(1000, 2000, 4000),
+	}
+	result.Indicators.AdjClose = []YahooAdjClose{{AdjClose: nullFloats(190, 95, 50)}}
+	// 2:1 splits on the second and third bar.
+	result.Events = &YahooEvents{Splits: map[string]YahooSplit{
+		"1704292200": {Date: 1704292200, Numerator: 2, Denominator: 1},
+		"1704378600": {Date: 1704378600, Numerator: 2, Denominator: 1},
+	}}
+
+	raw := newSeries(result, "1d")
+	if raw.Bars[0].AdjClose != 190 || raw.Bars[0].Close != 200 {
+		t.Errorf("Expected raw close 200 and adjusted close 190, got %+v", raw.Bars[0].PriceData)
+	}
+
+	adjusted := newSeries(result, "1d")
+	adjusted.adjust(HistoryQuery{AutoAdjust: true, AdjustVolume: true})
+	first := adjusted.Bars[0].PriceData
+	want := PriceData{Open: 190, High: 209, Low: 171, Close: 190, AdjClose: 190, Volume: 4000}
+	if first != want {
+		t.Errorf("Expected %+v, got %+v", want, first)
+	}
+	if v := adjusted.Bars[1].Volume; v != 4000 {
+		t.Errorf("Expected the second bar's volume to be adjusted for the later split only, got %d", v)
+	}
+	if v := adjusted.Bars[2].Volume; v != 4000 {
+		t.Errorf("Expected the last bar's volume to stay unchanged, got %d", v)
+	}
+}
+
+func TestSeriesAdjCloseFallback(t *testing.T) {
+	history := newHistoryWithClient(newReplayClient(t, "history"))
+	history.SetQuery(HistoryQuery{Range: "1d", Interval: "1m"})
+	resp, err := history.GetHistory("AAPL")
+	if err != nil {
+		t.Fatalf("GetHistory returned error: %v", err)
+	}
+	for _, bar := range newSeries(resp.Chart.Result[0], "1m").Bars {
+		if bar.AdjClose != bar.Close {
+			t.Fatalf("Expected intraday AdjClose to equal Close, got %+v", bar.PriceData)
+		}
+	}
+}
+
+func testTokyoResult(granularity string) YahooHistoryResult {
+	// Yahoo dates Tokyo daily bars at midnight JST, which is the previous day in UTC.
+	return YahooHistoryResult{
+		Meta: YahooMeta{
+			Symbol: "7203.T", DataGranularity: granularity,
+			Timezone: "JST", GmtOffset: 9 * 60 * 60, ExchangeTimezoneName: "Asia/Tokyo",
+		},
+		Timestamp: []int64{1748790000, 1748876400}, // 2025-06-02 and 2025-06-03 00:00 JST
+		Indicators: YahooIndicator{Quote: []YahooQuote{{
+			Open:   nullFloats(2700, 2710),
+			High:   nullFloats(2720, 2730),
+			Low:    nullFloats(2690, 2700),
+			Close:  nullFloats(2710, 2725),
+			Volume: nullInts(1000, 2000),
+		}}},
+	}
+}
+
+func TestSeriesExchangeTimezone(t *testing.T) {
+	s := newSeries(testTokyoResult("1d"), "1d")
+	if s.Location.String() != "Asia/Tokyo" {
+		t.Errorf("Expected the Asia/Tokyo location, got %v", s.Location)
+	}
+	if got := s.Bars[0].Time.Format("2006-01-02 15:04"); got != "2025-06-02 00:00" {
+		t.Errorf("Expected the first bar at midnight of 2025-06-02 Tokyo time, got %s", got)
+	}
+	if _, ok := s.Map()["2025-06-02"]; !ok {
+		t.Errorf("Expected the trading date 2025-06-02 as key, got %v", s.Map())
+	}
+
+	utc := s.In(time.UTC)
+	if !utc.Bars[0].Time.Equal(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the daily bar to keep its trading date in UTC, got %v", utc.Bars[0].Time)
+	}
+	if s.Bars[0].Time.Location() != s.Location {
+		t.Error("In modified the original series")
+	}
+
+	intraday := newSeries(testTokyoResult("1h"), "1h")
+	intraday.applyQuery(HistoryQuery{UTC: true})
+	if want := time.Unix(1748790000, 0).UTC(); intraday.Bars[0].Time != want {
+		t.Errorf("Expected the intraday bar at %v, got %v", want, intraday.Bars[0].Time)
+	}
+}
+
+func TestExchangeLocationFallback(t *testing.T) {
+	loc := exchangeLocation(YahooMeta{Timezone: "XYZ", GmtOffset: 3600, ExchangeTimezoneName: "Nowhere/Unknown"})
+	if name, offset := time.Unix(0, 0).In(loc).Zone(); name != "XYZ" || offset != 3600 {
+		t.Errorf("Expected a fixed XYZ+1 zone, got %s%+d", name, offset)
+	}
+	if loc := exchangeLocation(YahooMeta{}); loc != time.UTC {
+		t.Errorf("Expected UTC without timezone information, got %v", loc)
+	}
+}
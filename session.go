@@ -0,0 +1,84 @@
+package yahoofinanceapi
+
+import "sort"
+
+// Session is the trading session an intraday bar belongs to.
+type Session string
+
+const (
+	SessionPre     Session = "pre"
+	SessionRegular Session = "regular"
+	SessionPost    Session = "post"
+)
+
+// sessionPeriod is one session of one trading day, as Unix timestamps.
+type sessionPeriod struct {
+	start, end int64
+	session    Session
+}
+
+// tradingSessions tells the session of a timestamp from the trading periods of a chart.
+type tradingSessions struct {
+	periods []sessionPeriod // Sorted by start
+	current YahooCurrentTradingPeriod
+}
+
+func newTradingSessions(meta YahooMeta) tradingSessions {
+	ts := tradingSessions{current: meta.CurrentTradingPeriod}
+	add := func(days [][]YahooTradingPeriod, session Session) {
+		for _, day := range days {
+			for _, p := range day {
+				ts.periods = append(ts.periods, sessionPeriod{start: p.Start, end: p.End, session: session})
+			}
+		}
+	}
+	add(meta.TradingPeriods.Pre, SessionPre)
+	add(meta.TradingPeriods.Regular, SessionRegular)
+	add(meta.TradingPeriods.Post, SessionPost)
+	sort.Slice(ts.periods, func(i, j int) bool { return ts.periods[i].start < ts.periods[j].start })
+	return ts
+}
+
+// at returns the session of the bar starting at timestamp. Bars outside the trading periods of the
+// chart are placed by their time of day relative to the current regular session, or left untagged
+// if Yahoo reported no sessions at all.
+func (ts tradingSessions) at(timestamp int64) Session {
+	i := sort.Search(len(ts.periods), func(i int) bool { return ts.periods[i].end > timestamp })
+	if i < len(ts.periods) && ts.periods[i].start <= timestamp {
+		return ts.periods[i].session
+	}
+
+	regular := ts.current.Regular
+	if regular.Start == 0 && regular.End == 0 {
+		return ""
+	}
+	offset := int64(regular.GmtOffset)
+	timeOfDay := func(t int64) int64 {
+		const day = 24 * 60 * 60
+		return ((t+offset)%day + day) % day
+	}
+	switch t := timeOfDay(timestamp); {
+	case t < timeOfDay(regular.Start):
+		return SessionPre
+	case t < timeOfDay(regular.End):
+		return SessionRegular
+	default:
+		return SessionPost
+	}
+}
+
+// Sessions returns the bars of s that belong to one of the given sessions, e.g. SessionRegular for
+// regular trading hours only. The returned Series does not share its bars with s.
+func (s Series) Sessions(sessions ...Session) Series {
+	bars := make([]Bar, 0, len(s.Bars))
+	for _, bar := range s.Bars {
+		for _, session := range sessions {
+			if bar.Session == session {
+				bars = append(bars, bar)
+				break
+			}
+		}
+	}
+	s.Bars = bars
+	return s
+}
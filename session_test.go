@@ -0,0 +1,105 @@
+package yahoofinanceapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// testPrePostChartJSON is a 2 January 2024 chart with includePrePost: a pre-market, a regular and
+// a post-market bar, followed by a pre-market bar of a day missing from tradingPeriods.
+const testPrePostChartJSON = `{"chart":{"result":[{
+	"meta":{"symbol":"AAPL","dataGranularity":"1h","hasPrePostMarketData":true,
+		"currentTradingPeriod":{
+			"pre":{"timezone":"EST","start":1704186000,"end":1704205800,"gmtoffset":-18000},
+			"regular":{"timezone":"EST","start":1704205800,"end":1704229200,"gmtoffset":-18000},
+			"post":{"timezone":"EST","start":1704229200,"end":1704243600,"gmtoffset":-18000}},
+		"tradingPeriods":{
+			"pre":[[{"timezone":"EST","start":1704186000,"end":1704205800,"gmtoffset":-18000}]],
+			"regular":[[{"timezone":"EST","start":1704205800,"end":1704229200,"gmtoffset":-18000}]],
+			"post":[[{"timezone":"EST","start":1704229200,"end":1704243600,"gmtoffset":-18000}]]}},
+	"timestamp":[1704200400,1704205800,1704232800,1704286800],
+	"indicators":{"quote":[{
+		"open":[186.1,187.15,185.7,184.9],
+		"high":[186.4,188.44,185.9,185.2],
+		"low":[186.0,183.89,185.5,184.6],
+		"close":[186.2,185.64,185.6,185.0],
+		"volume":[12000,82488700,9000,15000]
+	}]}
+}],"error":null}}`
+
+func TestTradingPeriodsJSON(t *testing.T) {
+	var regularOnly YahooTradingPeriods
+	if err := json.Unmarshal([]byte(`[[{"start":1704205800,"end":1704229200}]]`), &regularOnly); err != nil {
+		t.Fatalf("Failed to decode the array form: %v", err)
+	}
+	if len(regularOnly.Regular) != 1 || regularOnly.Regular[0][0].Start != 1704205800 || regularOnly.Pre != nil {
+		t.Errorf("Expected the array to decode as regular sessions, got %+v", regularOnly)
+	}
+
+	var meta YahooMeta
+	if err := json.Unmarshal([]byte(`{"tradingPeriods":null}`), &meta); err != nil {
+		t.Fatalf("Failed to decode null periods: %v", err)
+	}
+
+	tests := []struct {
+		periods YahooTradingPeriods
+		want    string
+	}{
+		{YahooTradingPeriods{}, `null`},
+		{regularOnly, `[[{"timezone":"","end":1704229200,"start":1704205800,"gmtoffset":0}]]`},
+		{YahooTradingPeriods{Pre: regularOnly.Regular}, `{"pre":[[{"timezone":"","end":1704229200,"start":1704205800,"gmtoffset":0}]]}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.periods)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestSeriesSessions(t *testing.T) {
+	var gotPrePost string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPrePost = r.URL.Query().Get("includePrePost")
+		w.Write([]byte(testPrePostChartJSON))
+	})
+
+	series, err := NewTickerWithClient("AAPL", newTestClient(srv)).HistorySeries(HistoryQuery{Range: "5d", Interval: "1h", IncludePrePost: true})
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if gotPrePost != "true" {
+		t.Errorf("Expected includePrePost=true, got %q", gotPrePost)
+	}
+	// The last bar falls outside the trading periods and is placed by its time of day.
+	want := []Session{SessionPre, SessionRegular, SessionPost, SessionPre}
+	for i, bar := range series.Bars {
+		if bar.Session != want[i] {
+			t.Errorf("Bar %d: expected session %q, got %q", i, want[i], bar.Session)
+		}
+	}
+	if extended := series.Sessions(SessionPre, SessionPost); extended.Len() != 3 {
+		t.Errorf("Expected 3 extended-hours bars, got %d", extended.Len())
+	}
+}
+
+func TestSeriesSessionsReplay(t *testing.T) {
+	history := newHistoryWithClient(newReplayClient(t, "history"))
+	history.SetQuery(HistoryQuery{Range: "1d", Interval: "1m"})
+	resp, err := history.GetHistory("AAPL")
+	if err != nil {
+		t.Fatalf("GetHistory returned error: %v", err)
+	}
+	series := newSeries(resp.Chart.Result[0], "1m")
+	if regular := series.Sessions(SessionRegular); regular.Len() != series.Len() {
+		t.Errorf("Expected all %d bars in the regular session, got %d", series.Len(), regular.Len())
+	}
+
+	if daily := newSeries(testSeriesResult(), "1d"); daily.Bars[0].Session != "" {
+		t.Errorf("Expected no session on daily bars, got %q", daily.Bars[0].Session)
+	}
+}
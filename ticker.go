@@ -1,8 +1,8 @@
 package yahoofinanceapi
 
 import (
+	"context"
 	"fmt"
-	"sort"
 )
 
 type Ticker struct {
@@ -17,40 +17,50 @@ type Ticker struct {
 // It initializes the history, option, and information components needed to fetch
 // historical price data, options data, and ticker information.
 func NewTicker(symbol string) *Ticker {
-	h := newHistory()
-	o := newOption()
-	i := newInformation()
-	s := newSearch()
+	return NewTickerWithClient(symbol, getClient())
+}
+
+// NewTickerWithClient creates a new Ticker instance for the given symbol that sends all
+// requests through the given Client instead of the shared default one.
+// Use NewClient to configure timeouts, a proxy, a custom transport or a different base URL.
+func NewTickerWithClient(symbol string, client *Client) *Ticker {
+	h := newHistoryWithClient(client)
+	o := newOptionWithClient(client)
+	i := newInformationWithClient(client)
+	s := newSearchWithClient(client)
 	return &Ticker{Symbol: symbol, history: h, option: o, information: i, search: s}
 }
 
 // Quote returns the latest PriceData for the Ticker's symbol.
-// This is a convenience wrapper around the History function. It fetches the historical
-// price data for the symbol, sorts the dates, and returns the most recent entry.
+// This is a convenience wrapper around the History function that returns the most recent bar.
 // If you need more control or access to the full historical data, use the History method directly.
 func (t *Ticker) Quote() (PriceData, error) {
-	history, err := t.history.GetHistory(t.Symbol)
+	return t.QuoteContext(context.Background())
+}
+
+// QuoteContext is like Quote but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) QuoteContext(ctx context.Context) (PriceData, error) {
+	series, err := t.HistorySeriesContext(ctx, t.history.currentQuery())
 	if err != nil {
 		return PriceData{}, err
 	}
-	transformedData := t.history.transformData(history)
-
-	dates := make([]string, 0, len(transformedData))
-	for date := range transformedData {
-		dates = append(dates, date)
+	latest, ok := series.Latest()
+	if !ok {
+		return PriceData{}, fmt.Errorf("%w: no price data found for symbol: %s", ErrSymbolNotFound, t.Symbol)
 	}
-	sort.Strings(dates)
-
-	latestDate := dates[len(dates)-1]
-	latestPriceData := transformedData[latestDate]
-	return latestPriceData, nil
+	return latest.PriceData, nil
 }
 
 // Info retrieves the ticker information for the Ticker's symbol.
 // It returns a YahooTickerInfo struct containing metadata such as the symbol, name, currency, and market state.
 // If no information is found, it returns an error.
 func (t *Ticker) Info() (YahooTickerInfo, error) {
-	info, err := t.information.GetInfo(t.Symbol)
+	return t.InfoContext(context.Background())
+}
+
+// InfoContext is like Info but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) InfoContext(ctx context.Context) (YahooTickerInfo, error) {
+	info, err := t.information.GetInfoContext(ctx, t.Symbol)
 	if err != nil {
 		return YahooTickerInfo{}, err
 	}
@@ -64,36 +74,83 @@ func (t *Ticker) Info() (YahooTickerInfo, error) {
 // History retrieves the historical price data for the Ticker's symbol based on the provided query.
 // It returns a map of date strings to PriceData structs.
 // The query can specify the range, interval, and other parameters for the historical data.
+// Use HistorySeries to get the bars in order with their exact timestamps.
+// The query also becomes the one Quote fetches the latest bar with.
 func (t *Ticker) History(query HistoryQuery) (map[string]PriceData, error) {
+	return t.HistoryContext(context.Background(), query)
+}
+
+// HistoryContext is like History but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) HistoryContext(ctx context.Context, query HistoryQuery) (map[string]PriceData, error) {
 	t.history.SetQuery(query)
-	history, err := t.history.GetHistory(t.Symbol)
+	series, err := t.HistorySeriesContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	return t.history.transformData(history), nil
+	return series.Map(), nil
+}
+
+// HistorySeries retrieves the historical price data for the Ticker's symbol as a Series of bars
+// in chronological order.
+func (t *Ticker) HistorySeries(query HistoryQuery) (Series, error) {
+	return t.HistorySeriesContext(context.Background(), query)
+}
+
+// HistorySeriesContext is like HistorySeries but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) HistorySeriesContext(ctx context.Context, query HistoryQuery) (Series, error) {
+	query.SetDefault()
+	history, err := t.history.getHistory(ctx, t.Symbol, query)
+	if err != nil {
+		return Series{}, err
+	}
+	series := newSeries(history.Chart.Result[0], query.Interval)
+	series.applyQuery(query)
+	return series, nil
 }
 
 // OptionChain retrieves the option chain for the Ticker's symbol.
-// It returns an OptionData struct containing the options available for the ticker.
-// If no options are found, it returns an empty OptionData struct.
-func (t *Ticker) OptionChain() OptionData {
-	optionChain := t.option.GetOptionChain(t.Symbol)
+// It returns an OptionData struct containing the options of the nearest expiration date.
+// If the symbol does not exist or has no listed options, it returns an error
+// matching ErrSymbolNotFound or ErrNoOptions respectively.
+func (t *Ticker) OptionChain() (OptionData, error) {
+	return t.OptionChainContext(context.Background())
+}
+
+// OptionChainContext is like OptionChain but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) OptionChainContext(ctx context.Context) (OptionData, error) {
+	optionChain, err := t.option.GetOptionChainContext(ctx, t.Symbol)
+	if err != nil {
+		return OptionData{}, err
+	}
 	return t.option.transformData(optionChain)
 }
 
 // OptionChainByExpiration retrieves the option chain for the Ticker's symbol filtered by a specific expiration date.
 // It returns an OptionData struct containing the options available for the ticker on that expiration date.
-// If no options are found for the specified expiration, it returns an empty OptionData struct.
-func (t *Ticker) OptionChainByExpiration(expiration string) OptionData {
-	optionChain := t.option.GetOptionChainByExpiration(t.Symbol, expiration)
+// If the date cannot be parsed or no options are found for it, it returns an error.
+func (t *Ticker) OptionChainByExpiration(expiration string) (OptionData, error) {
+	return t.OptionChainByExpirationContext(context.Background(), expiration)
+}
+
+// OptionChainByExpirationContext is like OptionChainByExpiration but aborts the request when ctx is cancelled.
+func (t *Ticker) OptionChainByExpirationContext(ctx context.Context, expiration string) (OptionData, error) {
+	optionChain, err := t.option.GetOptionChainByExpirationContext(ctx, t.Symbol, expiration)
+	if err != nil {
+		return OptionData{}, err
+	}
 	return t.option.transformData(optionChain)
 }
 
 // ExpirationDates retrieves a list of available expiration dates for options on the Ticker's symbol.
-// It returns a slice of strings representing the expiration dates.
-func (t *Ticker) ExpirationDates() []string {
-	expirationDates := t.option.GetExpirationDates(t.Symbol)
-	return expirationDates
+// It returns a slice of strings representing the expiration dates, or an error matching
+// ErrNoOptions if the symbol has no listed options.
+func (t *Ticker) ExpirationDates() ([]string, error) {
+	return t.ExpirationDatesContext(context.Background())
+}
+
+// ExpirationDatesContext is like ExpirationDates but aborts the request when ctx is cancelled.
+func (t *Ticker) ExpirationDatesContext(ctx context.Context) ([]string, error) {
+	return t.option.GetExpirationDatesContext(ctx, t.Symbol)
 }
 
 // Search searches for investment symbols by query using Yahoo Finance's public search API.
@@ -107,7 +164,12 @@ func (t *Ticker) ExpirationDates() []string {
 //   - []SearchResult: List of search results
 //   - error: Error if request fails or query is invalid
 func (t *Ticker) Search(query string, limit int) ([]SearchResult, error) {
-	searchResponse, err := t.search.GetSearchResults(query, limit)
+	return t.SearchContext(context.Background(), query, limit)
+}
+
+// SearchContext is like Search but aborts the request when ctx is cancelled or its deadline passes.
+func (t *Ticker) SearchContext(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	searchResponse, err := t.search.GetSearchResultsContext(ctx, query, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +186,12 @@ func (t *Ticker) Search(query string, limit int) ([]SearchResult, error) {
 //   - []SearchResult: List of search results
 //   - error: Error if request fails or query is invalid
 func (t *Ticker) SearchWithOptions(params SearchParams) ([]SearchResult, error) {
-	searchResponse, err := t.search.GetSearchResultsWithOptions(params)
+	return t.SearchWithOptionsContext(context.Background(), params)
+}
+
+// SearchWithOptionsContext is like SearchWithOptions but aborts the request when ctx is cancelled.
+func (t *Ticker) SearchWithOptionsContext(ctx context.Context, params SearchParams) ([]SearchResult, error) {
+	searchResponse, err := t.search.GetSearchResultsWithOptionsContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
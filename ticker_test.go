@@ -1,6 +1,10 @@
 package yahoofinanceapi
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -15,7 +19,7 @@ func TestNewTicker(t *testing.T) {
 }
 
 func TestQuoteValidSymbol(t *testing.T) {
-	ticker := NewTicker("AAPL")
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
 	quote, err := ticker.Quote()
 	if err != nil {
 		t.Fatalf("Quote returned error: %v", err)
@@ -26,7 +30,7 @@ func TestQuoteValidSymbol(t *testing.T) {
 }
 
 func TestQuoteInvalidSymbol(t *testing.T) {
-	ticker := NewTicker("INVALID_SYMBOL_123")
+	ticker := NewTickerWithClient("INVALID_SYMBOL_123", newReplayClient(t, "ticker"))
 	_, err := ticker.Quote()
 	if err == nil {
 		t.Error("Expected error for invalid symbol, got nil")
@@ -34,7 +38,7 @@ func TestQuoteInvalidSymbol(t *testing.T) {
 }
 
 func TestGetInfoValidSymbol(t *testing.T) {
-	ticker := NewTicker("AAPL")
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
 	info, err := ticker.Info()
 	if err != nil {
 		t.Fatalf("GetInfo returned error: %v", err)
@@ -51,7 +55,7 @@ func TestGetInfoValidSymbol(t *testing.T) {
 }
 
 func TestGetInfoInvalidSymbol(t *testing.T) {
-	ticker := NewTicker("INVALID_SYMBOL_123")
+	ticker := NewTickerWithClient("INVALID_SYMBOL_123", newReplayClient(t, "ticker"))
 	_, err := ticker.Info()
 	if err == nil {
 		t.Error("Expected error for invalid symbol, got nil")
@@ -59,7 +63,7 @@ func TestGetInfoInvalidSymbol(t *testing.T) {
 }
 
 func TestHistoryValidSymbol(t *testing.T) {
-	ticker := NewTicker("AAPL")
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
 	query := HistoryQuery{Range: "1mo", Interval: "1d"}
 	data, err := ticker.History(query)
 	if err != nil {
@@ -71,7 +75,7 @@ func TestHistoryValidSymbol(t *testing.T) {
 }
 
 func TestHistoryInvalidSymbol(t *testing.T) {
-	ticker := NewTicker("INVALID_SYMBOL_123")
+	ticker := NewTickerWithClient("INVALID_SYMBOL_123", newReplayClient(t, "ticker"))
 	query := HistoryQuery{Range: "1mo", Interval: "1d"}
 	_, err := ticker.History(query)
 	if err == nil {
@@ -80,29 +84,110 @@ func TestHistoryInvalidSymbol(t *testing.T) {
 }
 
 func TestOptionChain(t *testing.T) {
-	ticker := NewTicker("AAPL")
-	data := ticker.OptionChain()
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
+	data, err := ticker.OptionChain()
+	if err != nil {
+		t.Fatalf("OptionChain returned error: %v", err)
+	}
 	if len(data.Calls) == 0 && len(data.Puts) == 0 {
 		t.Error("OptionChain returned empty calls and puts")
 	}
 }
 
 func TestOptionChainByExpiration(t *testing.T) {
-	ticker := NewTicker("AAPL")
-	dates := ticker.ExpirationDates()
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
+	dates, err := ticker.ExpirationDates()
+	if err != nil {
+		t.Fatalf("ExpirationDates returned error: %v", err)
+	}
 	if len(dates) == 0 {
 		t.Skip("No expiration dates available for AAPL")
 	}
-	data := ticker.OptionChainByExpiration(dates[0])
+	data, err := ticker.OptionChainByExpiration(dates[0])
+	if err != nil {
+		t.Fatalf("OptionChainByExpiration returned error: %v", err)
+	}
 	if len(data.Calls) == 0 && len(data.Puts) == 0 {
 		t.Error("OptionChainByExpiration returned empty calls and puts")
 	}
 }
 
 func TestExpirationDates(t *testing.T) {
-	ticker := NewTicker("AAPL")
-	dates := ticker.ExpirationDates()
+	ticker := NewTickerWithClient("AAPL", newReplayClient(t, "ticker"))
+	dates, err := ticker.ExpirationDates()
+	if err != nil {
+		t.Fatalf("ExpirationDates returned error: %v", err)
+	}
 	if len(dates) == 0 {
 		t.Error("ExpirationDates returned empty slice")
 	}
 }
+
+func TestHistoryConcurrentQueries(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		interval := r.URL.Query().Get("interval")
+		fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"symbol":"AAPL","dataGranularity":%q},"timestamp":[1704205800],"indicators":{"quote":[{"open":[1],"high":[2],"low":[0.5],"close":[1.5],"volume":[100]}]}}],"error":null}}`, interval)
+	})
+
+	ticker := NewTickerWithClient("AAPL", newTestClient(srv))
+	var wg sync.WaitGroup
+	for _, interval := range []Interval{"1d", "1m", "1wk", "5m"} {
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(interval Interval) {
+				defer wg.Done()
+				data, err := ticker.History(HistoryQuery{Range: "5d", Interval: interval})
+				if err != nil {
+					t.Errorf("History returned error: %v", err)
+					return
+				}
+				intraday := strings.HasSuffix(string(interval), "m")
+				for key := range data {
+					if hasTime := strings.Contains(key, ":"); hasTime != intraday {
+						t.Errorf("interval %s: unexpected key format %q", interval, key)
+					}
+				}
+			}(interval)
+		}
+	}
+	wg.Wait()
+}
+
+func TestHistoryDefaultQuery(t *testing.T) {
+	var ranges []string
+	var mu sync.Mutex
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.URL.Query().Get("range"))
+		mu.Unlock()
+		// No dataGranularity, so the series takes the interval of the query.
+		w.Write([]byte(`{"chart":{"result":[{"meta":{"symbol":"AAPL"},"timestamp":[1704205800],"indicators":{"quote":[{"open":[1],"high":[2],"low":[0.5],"close":[1.5],"volume":[100]}]}}],"error":null}}`))
+	})
+	ticker := NewTickerWithClient("AAPL", newTestClient(srv))
+
+	data, err := ticker.History(HistoryQuery{})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if _, ok := data["2024-01-02"]; !ok || len(data) != 1 {
+		t.Errorf("Expected the daily bar keyed by date, got %v", data)
+	}
+	series, err := ticker.HistorySeries(HistoryQuery{})
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if series.Interval != Interval1d {
+		t.Errorf("Expected the default interval 1d, got %q", series.Interval)
+	}
+
+	// Quote fetches the latest bar with the query of the last History call.
+	if _, err := ticker.History(HistoryQuery{Range: Range5d}); err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if _, err := ticker.Quote(); err != nil {
+		t.Fatalf("Quote returned error: %v", err)
+	}
+	if got := ranges[len(ranges)-1]; got != "5d" {
+		t.Errorf("Expected Quote to use the range of the last History query, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package yahoofinanceapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Tracer starts spans. It is a subset of OpenTelemetry's trace.Tracer, so an OpenTelemetry tracer
+// can be plugged in with a small adapter without this package depending on OpenTelemetry.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is a subset of OpenTelemetry's trace.Span.
+type Span interface {
+	// SetAttribute sets an attribute; values are strings or ints.
+	SetAttribute(key string, value any)
+	// RecordError marks the span as failed with err.
+	RecordError(err error)
+	End()
+}
+
+// TracingMiddleware returns a Middleware that wraps every request in a span named after its
+// endpoint, e.g. "yahoo_finance chart". Spans carry the OpenTelemetry HTTP client attributes
+// plus yahoo_finance.endpoint and yahoo_finance.symbol. The request continues with the span's
+// context, so middleware added after it can propagate the trace.
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info := RequestInfoFromRequest(req)
+			ctx, span := tracer.Start(req.Context(), "yahoo_finance "+info.Endpoint)
+			defer span.End()
+
+			span.SetAttribute("http.request.method", req.Method)
+			span.SetAttribute("server.address", req.URL.Hostname())
+			span.SetAttribute("url.path", req.URL.Path)
+			span.SetAttribute("yahoo_finance.endpoint", info.Endpoint)
+			if info.Symbol != "" {
+				span.SetAttribute("yahoo_finance.symbol", info.Symbol)
+			}
+
+			resp, err := next.RoundTrip(req.WithContext(ctx))
+			if err != nil {
+				span.RecordError(err)
+				return resp, err
+			}
+			span.SetAttribute("http.response.status_code", resp.StatusCode)
+			if resp.StatusCode >= 400 {
+				span.RecordError(fmt.Errorf("yahoo finance: HTTP %d", resp.StatusCode))
+			}
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package yfatest
+
+import (
+	"math"
+	"time"
+)
+
+// GenerateBars returns n bars starting at start and spaced by interval, with prices following a
+// deterministic walk around price. Daily or longer intervals skip Saturdays and Sundays.
+func GenerateBars(start time.Time, interval time.Duration, n int, price float64) []Bar {
+	bars := make([]Bar, 0, n)
+	t := start
+	for i := 0; len(bars) < n; i++ {
+		if interval >= 24*time.Hour {
+			for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+				t = t.Add(interval)
+			}
+		}
+		open := price
+		price = round2(price * (1 + 0.01*math.Sin(float64(i)*1.7)))
+		spread := round2(price * 0.005 * (1 + math.Abs(math.Cos(float64(i)))))
+		bars = append(bars, Bar{
+			Time:   t,
+			Open:   open,
+			High:   round2(math.Max(open, price) + spread),
+			Low:    round2(math.Min(open, price) - spread),
+			Close:  price,
+			Volume: int64(1_000_000 + 250_000*(i%7)),
+		})
+		t = t.Add(interval)
+	}
+	return bars
+}
+
+// GenerateChain returns a chain expiring at expiration with a call and a put per strike,
+// priced at intrinsic value plus a time value that shrinks away from spot.
+func GenerateChain(expiration time.Time, spot float64, strikes ...float64) Chain {
+	chain := Chain{Expiration: expiration}
+	for i, strike := range strikes {
+		timeValue := round2(math.Max(0.05, spot*0.02*math.Exp(-math.Abs(strike-spot)/(spot*0.1))))
+		call := round2(math.Max(0, spot-strike) + timeValue)
+		put := round2(math.Max(0, strike-spot) + timeValue)
+		volume := int64(100 * (i + 1))
+		chain.Calls = append(chain.Calls, Contract{
+			Strike: strike, LastPrice: call, Bid: round2(call - 0.05), Ask: round2(call + 0.05),
+			Volume: volume, OpenInterest: 10 * volume, ImpliedVolatility: 0.25,
+		})
+		chain.Puts = append(chain.Puts, Contract{
+			Strike: strike, LastPrice: put, Bid: round2(put - 0.05), Ask: round2(put + 0.05),
+			Volume: volume, OpenInterest: 10 * volume, ImpliedVolatility: 0.25,
+		})
+	}
+	return chain
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
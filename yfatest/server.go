@@ -0,0 +1,756 @@
+// Package yfatest provides a fake Yahoo Finance server for testing code built on yahoofinanceapi
+// without network access.
+//
+//	srv := yfatest.NewServer()
+//	defer srv.Close()
+//	srv.AddSymbol(yfatest.Symbol{Symbol: "AAPL", Bars: yfatest.GenerateBars(start, 24*time.Hour, 30, 190)})
+//	ticker := yfa.NewTickerWithClient("AAPL", srv.Client())
+//
+// The server hands out a cookie and crumb like Yahoo does and rejects data requests whose crumb
+// does not match, so a Client's session handling is exercised as well.
+package yfatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	yfa "github.com/oscarli916/yahoo-finance-api"
+)
+
+// Endpoint identifies one of the emulated Yahoo Finance endpoints.
+type Endpoint string
+
+const (
+	EndpointCrumb        Endpoint = "getcrumb"
+	EndpointChart        Endpoint = "chart"
+	EndpointOptions      Endpoint = "options"
+	EndpointQuoteSummary Endpoint = "quoteSummary"
+	EndpointSearch       Endpoint = "search"
+)
+
+// Bar is one OHLCV bar of a symbol's price history.
+type Bar struct {
+	Time     time.Time
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	AdjClose float64 // Reported for daily or longer intervals; 0 means equal to Close
+	Volume   int64
+	Missing  bool // Reported with null prices and volume, like an interval without trades
+}
+
+// Contract is one call or put of an option chain.
+type Contract struct {
+	Strike            float64
+	LastPrice         float64
+	Bid               float64
+	Ask               float64
+	Volume            int64
+	OpenInterest      int64
+	ImpliedVolatility float64
+}
+
+// Chain holds the contracts expiring on one date.
+type Chain struct {
+	Expiration time.Time // Midnight UTC of the expiration date, as Yahoo reports it
+	Calls      []Contract
+	Puts       []Contract
+}
+
+// Symbol is the data the server returns for one ticker symbol.
+// Empty descriptive fields get defaults typical for a US equity. Intraday bars outside regular
+// trading hours, 9:30 to 16:00 in Timezone, are only returned when includePrePost is requested.
+type Symbol struct {
+	Symbol    string
+	ShortName string
+	LongName  string
+	Currency  string // Defaults to USD
+	Exchange  string // Defaults to NMS
+	QuoteType string // Defaults to EQUITY
+	Timezone  string // IANA name of the exchange timezone; defaults to America/New_York
+	Bars      []Bar  // Price history in chronological order; the last bar drives the quote
+	Chains    []Chain
+
+	// Corporate actions reported by the chart endpoint when it is asked for events.
+	Dividends    []yfa.Dividend
+	Splits       []yfa.Split
+	CapitalGains []yfa.CapitalGain
+}
+
+// fault is an injected error response.
+type fault struct {
+	status    int
+	remaining int
+}
+
+// Server is a fake Yahoo Finance API backed by an httptest.Server. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	symbols  map[string]Symbol
+	faults   map[Endpoint]*fault
+	latency  time.Duration
+	crumb    string
+	sessions int
+	requests map[Endpoint]int
+}
+
+// NewServer starts a Server without any symbols. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		symbols:  make(map[string]Symbol),
+		faults:   make(map[Endpoint]*fault),
+		requests: make(map[Endpoint]int),
+	}
+	s.rotateCrumb()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cookie", s.handleCookie)
+	mux.HandleFunc("/v1/test/getcrumb", s.wrap(EndpointCrumb, false, s.handleCrumb))
+	mux.HandleFunc("/v8/finance/chart/", s.wrap(EndpointChart, true, s.handleChart))
+	mux.HandleFunc("/v7/finance/options/", s.wrap(EndpointOptions, true, s.handleOptions))
+	mux.HandleFunc("/v10/finance/quoteSummary/", s.wrap(EndpointQuoteSummary, true, s.handleQuoteSummary))
+	mux.HandleFunc("/v1/finance/search", s.wrap(EndpointSearch, true, s.handleSearch))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a Client wired to the server. It retries with millisecond delays so injected
+// errors do not slow tests down; opts are applied afterwards and may override that.
+func (s *Server) Client(opts ...yfa.ClientOption) *yfa.Client {
+	defaults := []yfa.ClientOption{
+		yfa.WithBaseURL(s.URL),
+		yfa.WithCookieURL(s.URL + "/cookie"),
+		yfa.WithRetryPolicy(yfa.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	}
+	return yfa.NewClient(append(defaults, opts...)...)
+}
+
+// AddSymbol adds a symbol, replacing any previous data for it.
+func (s *Server) AddSymbol(symbol Symbol) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.symbols[strings.ToUpper(symbol.Symbol)] = symbol
+}
+
+// RemoveSymbol makes the server answer requests for symbol as Yahoo does for unknown symbols.
+func (s *Server) RemoveSymbol(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.symbols, strings.ToUpper(symbol))
+}
+
+// FailNext makes the next n requests to endpoint fail with status, e.g. 401, 429 or 500.
+// It replaces any failures still pending for that endpoint.
+func (s *Server) FailNext(endpoint Endpoint, status, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = &fault{status: status, remaining: n}
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// ExpireSession issues a new crumb, so requests with the previous one are rejected with 401.
+func (s *Server) ExpireSession() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotateCrumb()
+}
+
+// Requests returns how many requests endpoint has received, including rejected ones.
+func (s *Server) Requests(endpoint Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[endpoint]
+}
+
+// rotateCrumb must be called with s.mu held.
+func (s *Server) rotateCrumb() {
+	s.sessions++
+	s.crumb = fmt.Sprintf("yfatest-crumb-%d", s.sessions)
+}
+
+// lookup returns the data of symbol.
+func (s *Server) lookup(symbol string) (Symbol, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.symbols[strings.ToUpper(symbol)]
+	return data, ok
+}
+
+// wrap counts requests to endpoint and applies latency, injected failures and, if checkCrumb is set, crumb validation.
+func (s *Server) wrap(endpoint Endpoint, checkCrumb bool, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[endpoint]++
+		latency := s.latency
+		crumb := s.crumb
+		status := 0
+		if f := s.faults[endpoint]; f != nil && f.remaining > 0 {
+			f.remaining--
+			status = f.status
+		}
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if status != 0 {
+			writeFault(w, status)
+			return
+		}
+		if checkCrumb && r.URL.Query().Get("crumb") != crumb {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{
+				"finance": map[string]any{"result": nil, "error": yfa.YahooError{Code: "Unauthorized", Description: "Invalid Crumb"}},
+			})
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// writeFault writes an error response shaped like the ones Yahoo sends for status.
+func writeFault(w http.ResponseWriter, status int) {
+	if status == http.StatusTooManyRequests {
+		// Yahoo answers rate limited requests with a plain text body.
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		w.Write([]byte("Too Many Requests\r\n"))
+		return
+	}
+	writeJSON(w, status, map[string]any{
+		"finance": map[string]any{"result": nil, "error": yfa.YahooError{Code: http.StatusText(status), Description: "Injected by yfatest"}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) handleCookie(w http.ResponseWriter, r *http.Request) {
+	// Like fc.yahoo.com, the cookie comes with a 404.
+	http.SetCookie(w, &http.Cookie{Name: "A3", Value: "yfatest", Path: "/"})
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (s *Server) handleCrumb(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	crumb := s.crumb
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain;charset=utf-8")
+	w.Write([]byte(crumb))
+}
+
+func (s *Server) handleChart(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/v8/finance/chart/")
+	data, ok := s.lookup(symbol)
+	if !ok || len(data.Bars) == 0 {
+		writeJSON(w, http.StatusNotFound, map[string]any{
+			"chart": map[string]any{"result": nil, "error": yfa.YahooError{Code: "Not Found", Description: "No data found, symbol may be delisted"}},
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	bars, from, to, err := selectBars(data.Bars, query.Get("range"), query.Get("period1"), query.Get("period2"))
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+			"chart": map[string]any{"result": nil, "error": yfa.YahooError{Code: "Unprocessable Entity", Description: err.Error()}},
+		})
+		return
+	}
+
+	interval := query.Get("interval")
+	result := yfa.YahooHistoryResult{Meta: chartMeta(data, interval, query.Get("range"))}
+	if !dailyOrLonger(interval) {
+		bars, result.Meta.TradingPeriods = sessionBars(bars, location(data), query.Get("includePrePost") == "true")
+	}
+	quote := yfa.YahooQuote{}
+	adjClose := yfa.YahooAdjClose{}
+	for _, bar := range bars {
+		result.Timestamp = append(result.Timestamp, bar.Time.Unix())
+		if bar.Missing {
+			quote.Open = append(quote.Open, nil)
+			quote.High = append(quote.High, nil)
+			quote.Low = append(quote.Low, nil)
+			quote.Close = append(quote.Close, nil)
+			quote.Volume = append(quote.Volume, nil)
+			adjClose.AdjClose = append(adjClose.AdjClose, nil)
+			continue
+		}
+		quote.Open = append(quote.Open, &bar.Open)
+		quote.High = append(quote.High, &bar.High)
+		quote.Low = append(quote.Low, &bar.Low)
+		quote.Close = append(quote.Close, &bar.Close)
+		quote.Volume = append(quote.Volume, &bar.Volume)
+		if bar.AdjClose != 0 {
+			adjClose.AdjClose = append(adjClose.AdjClose, &bar.AdjClose)
+		} else {
+			adjClose.AdjClose = append(adjClose.AdjClose, &bar.Close)
+		}
+	}
+	result.Indicators.Quote = []yfa.YahooQuote{quote}
+	if dailyOrLonger(interval) {
+		// Like Yahoo, adjusted closes are only reported for daily or longer intervals.
+		result.Indicators.AdjClose = []yfa.YahooAdjClose{adjClose}
+	}
+	result.Events = chartEvents(data, query.Get("events"), from, to)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"chart": map[string]any{"result": []yfa.YahooHistoryResult{result}, "error": nil},
+	})
+}
+
+// selectBars returns the bars within the requested range, counted back from the last bar,
+// or within [period1, period2) if no range is given, along with the bounds of that period.
+func selectBars(bars []Bar, rng, period1, period2 string) ([]Bar, time.Time, time.Time, error) {
+	if rng == "" && period1 == "" {
+		rng = "1mo"
+	}
+	var from, to time.Time
+	if rng != "" {
+		last := bars[len(bars)-1].Time
+		switch rng {
+		case "max":
+			from = bars[0].Time
+		case "ytd":
+			from = time.Date(last.Year(), 1, 1, 0, 0, 0, 0, last.Location())
+		default:
+			var err error
+			if from, err = rangeStart(last, rng); err != nil {
+				return nil, from, to, err
+			}
+		}
+		to = last.Add(time.Nanosecond)
+	} else {
+		start, err := strconv.ParseInt(period1, 10, 64)
+		if err != nil {
+			return nil, from, to, fmt.Errorf("Invalid input - start date cannot be parsed: %s", period1)
+		}
+		end := time.Now().Unix()
+		if period2 != "" {
+			if end, err = strconv.ParseInt(period2, 10, 64); err != nil {
+				return nil, from, to, fmt.Errorf("Invalid input - end date cannot be parsed: %s", period2)
+			}
+		}
+		if end < start {
+			return nil, from, to, fmt.Errorf("Invalid input - start date cannot be after end date. startDate = %d, endDate = %d", start, end)
+		}
+		from, to = time.Unix(start, 0), time.Unix(end, 0)
+	}
+
+	var selected []Bar
+	for _, bar := range bars {
+		if !bar.Time.Before(from) && bar.Time.Before(to) {
+			selected = append(selected, bar)
+		}
+	}
+	return selected, from, to, nil
+}
+
+// chartEvents returns the events of data dated in [from, to) that the events parameter asks for,
+// or nil if there are none.
+func chartEvents(data Symbol, events string, from, to time.Time) *yfa.YahooEvents {
+	in := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+	key := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+	result := &yfa.YahooEvents{}
+	for _, kind := range strings.Split(events, ",") {
+		switch kind {
+		case "div":
+			for _, d := range data.Dividends {
+				if in(d.Date) {
+					if result.Dividends == nil {
+						result.Dividends = make(map[string]yfa.YahooDividend)
+					}
+					result.Dividends[key(d.Date)] = yfa.YahooDividend{Amount: d.Amount, Date: d.Date.Unix()}
+				}
+			}
+		case "splits":
+			for _, s := range data.Splits {
+				if in(s.Date) {
+					if result.Splits == nil {
+						result.Splits = make(map[string]yfa.YahooSplit)
+					}
+					ratio := s.Ratio
+					if ratio == "" {
+						ratio = strconv.FormatFloat(s.Numerator, 'f', -1, 64) + ":" + strconv.FormatFloat(s.Denominator, 'f', -1, 64)
+					}
+					result.Splits[key(s.Date)] = yfa.YahooSplit{Date: s.Date.Unix(), Numerator: s.Numerator, Denominator: s.Denominator, SplitRatio: ratio}
+				}
+			}
+		case "capitalGains":
+			for _, g := range data.CapitalGains {
+				if in(g.Date) {
+					if result.CapitalGains == nil {
+						result.CapitalGains = make(map[string]yfa.YahooCapitalGain)
+					}
+					result.CapitalGains[key(g.Date)] = yfa.YahooCapitalGain{Amount: g.Amount, Date: g.Date.Unix()}
+				}
+			}
+		}
+	}
+	if result.Dividends == nil && result.Splits == nil && result.CapitalGains == nil {
+		// Yahoo leaves out the events block when there are none.
+		return nil
+	}
+	return result
+}
+
+// sessionBars returns the intraday bars that fall into the regular session of their trading day,
+// or into any session with includePrePost, along with the trading periods of the days they cover.
+func sessionBars(bars []Bar, loc *time.Location, includePrePost bool) ([]Bar, yfa.YahooTradingPeriods) {
+	var selected []Bar
+	var periods yfa.YahooTradingPeriods
+	var current yfa.YahooCurrentTradingPeriod
+	for _, bar := range bars {
+		day := tradingDay(bar.Time, loc)
+		start, end := day.Regular.Start, day.Regular.End
+		if includePrePost {
+			start, end = day.Pre.Start, day.Post.End
+		}
+		if t := bar.Time.Unix(); t < start || t >= end {
+			continue
+		}
+		selected = append(selected, bar)
+		if day != current {
+			current = day
+			periods.Regular = append(periods.Regular, []yfa.YahooTradingPeriod{day.Regular})
+			if includePrePost {
+				periods.Pre = append(periods.Pre, []yfa.YahooTradingPeriod{day.Pre})
+				periods.Post = append(periods.Post, []yfa.YahooTradingPeriod{day.Post})
+			}
+		}
+	}
+	return selected, periods
+}
+
+// tradingDay returns the sessions of the trading day of t with US equity hours: pre-market from
+// 4:00, regular trading from 9:30 and post-market from 16:00 to 20:00 in loc.
+func tradingDay(t time.Time, loc *time.Location) yfa.YahooCurrentTradingPeriod {
+	local := t.In(loc)
+	period := func(startMinute, endMinute int) yfa.YahooTradingPeriod {
+		start := time.Date(local.Year(), local.Month(), local.Day(), 0, startMinute, 0, 0, loc)
+		end := time.Date(local.Year(), local.Month(), local.Day(), 0, endMinute, 0, 0, loc)
+		zone, offset := start.Zone()
+		return yfa.YahooTradingPeriod{Timezone: zone, Start: start.Unix(), End: end.Unix(), GmtOffset: offset}
+	}
+	return yfa.YahooCurrentTradingPeriod{
+		Pre:     period(4*60, 9*60+30),
+		Regular: period(9*60+30, 16*60),
+		Post:    period(16*60, 20*60),
+	}
+}
+
+// dailyOrLonger reports whether interval is a daily, weekly or monthly interval.
+func dailyOrLonger(interval string) bool {
+	return strings.HasSuffix(interval, "d") || strings.HasSuffix(interval, "wk") || strings.HasSuffix(interval, "mo")
+}
+
+// rangeStart returns the start of a range such as 5d or 6mo ending at last.
+func rangeStart(last time.Time, rng string) (time.Time, error) {
+	for _, unit := range []string{"d", "mo", "y"} {
+		n, err := strconv.Atoi(strings.TrimSuffix(rng, unit))
+		if !strings.HasSuffix(rng, unit) || err != nil || n <= 0 {
+			continue
+		}
+		switch unit {
+		case "d":
+			// The last trading day counts as the first day of the range.
+			day := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, last.Location())
+			return day.AddDate(0, 0, 1-n), nil
+		case "mo":
+			return last.AddDate(0, -n, 0), nil
+		case "y":
+			return last.AddDate(-n, 0, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid range requested: %s", rng)
+}
+
+func chartMeta(data Symbol, interval, rng string) yfa.YahooMeta {
+	data = withDefaults(data)
+	loc := location(data)
+	last := data.Bars[len(data.Bars)-1]
+	zone, offset := last.Time.In(loc).Zone()
+	meta := yfa.YahooMeta{
+		Currency:             data.Currency,
+		Symbol:               data.Symbol,
+		ExchangeName:         data.Exchange,
+		FullExchangeName:     data.Exchange,
+		InstrumentType:       data.QuoteType,
+		FirstTradeDate:       data.Bars[0].Time.Unix(),
+		RegularMarketTime:    last.Time.Unix(),
+		GmtOffset:            offset,
+		Timezone:             zone,
+		ExchangeTimezoneName: data.Timezone,
+		RegularMarketPrice:   last.Close,
+		RegularMarketDayHigh: last.High,
+		RegularMarketDayLow:  last.Low,
+		RegularMarketVolume:  last.Volume,
+		LongName:             data.LongName,
+		ShortName:            data.ShortName,
+		PriceHint:            2,
+		CurrentTradingPeriod: tradingDay(last.Time, loc),
+		DataGranularity:      interval,
+		Range:                rng,
+		ValidRanges:          []string{"1d", "5d", "1mo", "3mo", "6mo", "1y", "2y", "5y", "10y", "ytd", "max"},
+	}
+	meta.FiftyTwoWeekHigh, meta.FiftyTwoWeekLow = last.High, last.Low
+	for _, bar := range data.Bars {
+		meta.FiftyTwoWeekHigh = math.Max(meta.FiftyTwoWeekHigh, bar.High)
+		meta.FiftyTwoWeekLow = math.Min(meta.FiftyTwoWeekLow, bar.Low)
+	}
+	if len(data.Bars) > 1 {
+		meta.ChartPreviousClose = data.Bars[len(data.Bars)-2].Close
+		meta.PreviousClose = meta.ChartPreviousClose
+	}
+	return meta
+}
+
+func (s *Server) handleOptions(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/v7/finance/options/")
+	data, ok := s.lookup(symbol)
+	if !ok {
+		// Yahoo reports unknown symbols with an empty result rather than an error.
+		writeJSON(w, http.StatusOK, map[string]any{"optionChain": map[string]any{"result": []any{}, "error": nil}})
+		return
+	}
+	data = withDefaults(data)
+
+	chains := append([]Chain(nil), data.Chains...)
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Expiration.Before(chains[j].Expiration) })
+	result := yfa.YahooOptionResult{
+		UnderlyingSymbol: data.Symbol,
+		ExpirationDates:  []int64{},
+		Strikes:          []float64{},
+		Quote:            yfa.YahooOptionQuote{Symbol: data.Symbol, ShortName: data.ShortName, LongName: data.LongName, Currency: data.Currency, QuoteType: data.QuoteType},
+		Options:          []yfa.YahooOptions{},
+	}
+	if len(data.Bars) > 0 {
+		result.Quote.RegularMarketPrice = data.Bars[len(data.Bars)-1].Close
+	}
+	for _, chain := range chains {
+		result.ExpirationDates = append(result.ExpirationDates, chain.Expiration.Unix())
+	}
+
+	var selected *Chain
+	if date := r.URL.Query().Get("date"); date != "" {
+		for i := range chains {
+			if strconv.FormatInt(chains[i].Expiration.Unix(), 10) == date {
+				selected = &chains[i]
+			}
+		}
+	} else if len(chains) > 0 {
+		selected = &chains[0]
+	}
+	if selected != nil {
+		strikes := make(map[float64]bool)
+		options := yfa.YahooOptions{
+			ExpirationDate: selected.Expiration.Unix(),
+			Calls:          contracts(data, *selected, selected.Calls, "C", strikes),
+			Puts:           contracts(data, *selected, selected.Puts, "P", strikes),
+		}
+		for strike := range strikes {
+			result.Strikes = append(result.Strikes, strike)
+		}
+		sort.Float64s(result.Strikes)
+		result.Options = append(result.Options, options)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"optionChain": map[string]any{"result": []yfa.YahooOptionResult{result}, "error": nil},
+	})
+}
+
+// contracts converts the calls or puts of chain, recording their strikes.
+func contracts(data Symbol, chain Chain, list []Contract, kind string, strikes map[float64]bool) []yfa.YahooOption {
+	var spot float64
+	if len(data.Bars) > 0 {
+		spot = data.Bars[len(data.Bars)-1].Close
+	}
+	options := []yfa.YahooOption{}
+	for _, c := range list {
+		strikes[c.Strike] = true
+		inTheMoney := c.Strike < spot
+		if kind == "P" {
+			inTheMoney = c.Strike > spot
+		}
+		options = append(options, yfa.YahooOption{
+			ContractSymbol:    fmt.Sprintf("%s%s%s%08d", data.Symbol, chain.Expiration.UTC().Format("060102"), kind, int64(math.Round(c.Strike*1000))),
+			Strike:            c.Strike,
+			Currency:          data.Currency,
+			LastPrice:         c.LastPrice,
+			Volume:            c.Volume,
+			OpenInterest:      c.OpenInterest,
+			Bid:               c.Bid,
+			Ask:               c.Ask,
+			ContractSize:      "REGULAR",
+			Expiration:        chain.Expiration.Unix(),
+			ImpliedVolatility: c.ImpliedVolatility,
+			InTheMoney:        inTheMoney && spot > 0,
+		})
+	}
+	return options
+}
+
+func (s *Server) handleQuoteSummary(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/v10/finance/quoteSummary/")
+	data, ok := s.lookup(symbol)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]any{
+			"quoteSummary": map[string]any{"result": nil, "error": yfa.YahooError{Code: "Not Found", Description: "Quote not found for symbol: " + symbol}},
+		})
+		return
+	}
+	data = withDefaults(data)
+
+	info := yfa.YahooTickerInfo{
+		Symbol:      data.Symbol,
+		ShortName:   data.ShortName,
+		LongName:    data.LongName,
+		Currency:    data.Currency,
+		Exchange:    data.Exchange,
+		QuoteType:   data.QuoteType,
+		MarketState: "CLOSED",
+	}
+	if n := len(data.Bars); n > 0 {
+		last := data.Bars[n-1]
+		info.RegularMarketTime = last.Time.Unix()
+		info.RegularMarketPrice = priceValue(last.Close)
+		info.RegularMarketOpen = priceValue(last.Open)
+		info.RegularMarketDayHigh = priceValue(last.High)
+		info.RegularMarketDayLow = priceValue(last.Low)
+		info.RegularMarketVolume = &yfa.PriceValue{Raw: float64(last.Volume), Fmt: strconv.FormatInt(last.Volume, 10)}
+		if n > 1 {
+			previous := data.Bars[n-2].Close
+			info.RegularMarketPreviousClose = priceValue(previous)
+			info.RegularMarketChange = priceValue(last.Close - previous)
+			if previous != 0 {
+				change := (last.Close - previous) / previous
+				info.RegularMarketChangePercent = &yfa.PriceValue{Raw: change, Fmt: fmt.Sprintf("%.2f%%", change*100)}
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"quoteSummary": map[string]any{"result": []map[string]any{{"price": info}}, "error": nil},
+	})
+}
+
+func priceValue(v float64) *yfa.PriceValue {
+	return &yfa.PriceValue{Raw: v, Fmt: fmt.Sprintf("%.2f", v)}
+}
+
+// searchQuote is a quote of the search response.
+type searchQuote struct {
+	Symbol    string `json:"symbol"`
+	ShortName string `json:"shortname"`
+	LongName  string `json:"longname,omitempty"`
+	QuoteType string `json:"quoteType"`
+	Exchange  string `json:"exchange"`
+	ExchDisp  string `json:"exchDisp"`
+	TypeDisp  string `json:"typeDisp"`
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := strings.ToLower(strings.TrimSpace(query.Get("q")))
+	count, err := strconv.Atoi(query.Get("quotesCount"))
+	if err != nil {
+		count = 10
+	}
+
+	s.mu.Lock()
+	var matches []Symbol
+	for _, data := range s.symbols {
+		if q != "" && (strings.Contains(strings.ToLower(data.Symbol), q) ||
+			strings.Contains(strings.ToLower(data.ShortName), q) ||
+			strings.Contains(strings.ToLower(data.LongName), q)) {
+			matches = append(matches, withDefaults(data))
+		}
+	}
+	s.mu.Unlock()
+
+	// Exact symbol matches first, then alphabetically, so results are deterministic.
+	sort.Slice(matches, func(i, j int) bool {
+		exactI, exactJ := strings.ToLower(matches[i].Symbol) == q, strings.ToLower(matches[j].Symbol) == q
+		if exactI != exactJ {
+			return exactI
+		}
+		return matches[i].Symbol < matches[j].Symbol
+	})
+	if len(matches) > count {
+		matches = matches[:count]
+	}
+
+	quotes := []searchQuote{}
+	for _, data := range matches {
+		quotes = append(quotes, searchQuote{
+			Symbol:    data.Symbol,
+			ShortName: data.ShortName,
+			LongName:  data.LongName,
+			QuoteType: data.QuoteType,
+			Exchange:  data.Exchange,
+			ExchDisp:  data.Exchange,
+			TypeDisp:  typeDisp(data.QuoteType),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count": len(quotes), "quotes": quotes, "news": []any{}, "lists": []any{},
+	})
+}
+
+func typeDisp(quoteType string) string {
+	if quoteType == "ETF" {
+		return quoteType
+	}
+	return strings.ToUpper(quoteType[:1]) + strings.ToLower(quoteType[1:])
+}
+
+// location returns the exchange timezone of data, falling back to UTC for an unknown name.
+func location(data Symbol) *time.Location {
+	loc, err := time.LoadLocation(withDefaults(data).Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// withDefaults fills in the descriptive fields left empty.
+func withDefaults(data Symbol) Symbol {
+	data.Symbol = strings.ToUpper(data.Symbol)
+	if data.ShortName == "" {
+		data.ShortName = data.Symbol
+	}
+	if data.Currency == "" {
+		data.Currency = "USD"
+	}
+	if data.Exchange == "" {
+		data.Exchange = "NMS"
+	}
+	if data.QuoteType == "" {
+		data.QuoteType = "EQUITY"
+	}
+	if data.Timezone == "" {
+		data.Timezone = "America/New_York"
+	}
+	return data
+}
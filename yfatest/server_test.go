@@ -0,0 +1,291 @@
+package yfatest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	yfa "github.com/oscarli916/yahoo-finance-api"
+)
+
+var testStart = time.Date(2025, 1, 2, 14, 30, 0, 0, time.UTC)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddSymbol(Symbol{
+		Symbol:    "AAPL",
+		ShortName: "Apple Inc.",
+		LongName:  "Apple Inc.",
+		Bars:      GenerateBars(testStart, 24*time.Hour, 60, 190),
+		Chains: []Chain{
+			GenerateChain(time.Date(2025, 4, 17, 0, 0, 0, 0, time.UTC), 190, 180, 190, 200),
+			GenerateChain(time.Date(2025, 4, 4, 0, 0, 0, 0, time.UTC), 190, 185, 195),
+		},
+	})
+	srv.AddSymbol(Symbol{Symbol: "SPY", ShortName: "SPDR S&P 500", QuoteType: "ETF", Bars: GenerateBars(testStart, 24*time.Hour, 5, 590)})
+	return srv
+}
+
+func TestServerHistory(t *testing.T) {
+	srv := newTestServer(t)
+	ticker := yfa.NewTickerWithClient("AAPL", srv.Client())
+
+	all, err := ticker.History(yfa.HistoryQuery{Range: "max", Interval: "1d"})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if len(all) != 60 {
+		t.Errorf("Expected 60 bars, got %d", len(all))
+	}
+
+	week, err := ticker.History(yfa.HistoryQuery{Range: "5d", Interval: "1d"})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if len(week) == 0 || len(week) > 5 {
+		t.Errorf("Expected up to 5 bars for 5d, got %d", len(week))
+	}
+
+	period, err := ticker.History(yfa.HistoryQuery{
+		Start:    time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+		Interval: "1d",
+	})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if len(period) != 3 {
+		t.Errorf("Expected 3 bars between Jan 6 and Jan 9, got %d", len(period))
+	}
+}
+
+func TestServerQuoteAndInfo(t *testing.T) {
+	srv := newTestServer(t)
+	ticker := yfa.NewTickerWithClient("AAPL", srv.Client())
+
+	info, err := ticker.Info()
+	if err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+	if info.ShortName != "Apple Inc." || info.Currency != "USD" {
+		t.Errorf("Unexpected info %+v", info)
+	}
+	quote, err := ticker.Quote()
+	if err != nil {
+		t.Fatalf("Quote returned error: %v", err)
+	}
+	if info.RegularMarketPrice == nil || quote.Close != info.RegularMarketPrice.Raw {
+		t.Errorf("Expected quote close %v to match the info price %+v", quote.Close, info.RegularMarketPrice)
+	}
+}
+
+func TestServerOptions(t *testing.T) {
+	srv := newTestServer(t)
+	ticker := yfa.NewTickerWithClient("AAPL", srv.Client())
+
+	dates, err := ticker.ExpirationDates()
+	if err != nil {
+		t.Fatalf("ExpirationDates returned error: %v", err)
+	}
+	if len(dates) != 2 || dates[0] != "2025-04-04" || dates[1] != "2025-04-17" {
+		t.Fatalf("Expected sorted expiration dates, got %v", dates)
+	}
+	nearest, err := ticker.OptionChain()
+	if err != nil {
+		t.Fatalf("OptionChain returned error: %v", err)
+	}
+	if len(nearest.Calls) != 2 || len(nearest.Puts) != 2 {
+		t.Errorf("Expected the nearest chain with 2 calls and 2 puts, got %d and %d", len(nearest.Calls), len(nearest.Puts))
+	}
+	later, err := ticker.OptionChainByExpiration(dates[1])
+	if err != nil {
+		t.Fatalf("OptionChainByExpiration returned error: %v", err)
+	}
+	if len(later.Calls) != 3 {
+		t.Errorf("Expected 3 calls, got %d", len(later.Calls))
+	}
+	if later.Calls[0].ContractSymbol != "AAPL250417C00180000" {
+		t.Errorf("Unexpected contract symbol %s", later.Calls[0].ContractSymbol)
+	}
+
+	if _, err := yfa.NewTickerWithClient("SPY", srv.Client()).OptionChain(); !errors.Is(err, yfa.ErrNoOptions) {
+		t.Errorf("Expected ErrNoOptions for a symbol without chains, got %v", err)
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	srv := newTestServer(t)
+	results, err := yfa.NewTickerWithClient("AAPL", srv.Client()).Search("spdr", 10)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Symbol != "SPY" || results[0].Type != "ETF" {
+		t.Errorf("Unexpected search results %+v", results)
+	}
+}
+
+func TestServerUnknownSymbol(t *testing.T) {
+	srv := newTestServer(t)
+	ticker := yfa.NewTickerWithClient("MISSING", srv.Client())
+	if _, err := ticker.History(yfa.HistoryQuery{}); !errors.Is(err, yfa.ErrSymbolNotFound) {
+		t.Errorf("History: expected ErrSymbolNotFound, got %v", err)
+	}
+	if _, err := ticker.Info(); !errors.Is(err, yfa.ErrSymbolNotFound) {
+		t.Errorf("Info: expected ErrSymbolNotFound, got %v", err)
+	}
+	if _, err := ticker.OptionChain(); !errors.Is(err, yfa.ErrSymbolNotFound) {
+		t.Errorf("OptionChain: expected ErrSymbolNotFound, got %v", err)
+	}
+}
+
+func TestServerFailNext(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ticker := yfa.NewTickerWithClient("AAPL", client)
+
+	srv.FailNext(EndpointQuoteSummary, http.StatusInternalServerError, 2)
+	if _, err := ticker.Info(); err != nil {
+		t.Fatalf("Expected the client to retry past two failures, got %v", err)
+	}
+	if n := srv.Requests(EndpointQuoteSummary); n != 3 {
+		t.Errorf("Expected 3 quoteSummary requests, got %d", n)
+	}
+
+	srv.FailNext(EndpointChart, http.StatusTooManyRequests, 3)
+	if _, err := ticker.History(yfa.HistoryQuery{}); !errors.Is(err, yfa.ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited once retries run out, got %v", err)
+	}
+}
+
+func TestServerSessionExpiry(t *testing.T) {
+	srv := newTestServer(t)
+	ticker := yfa.NewTickerWithClient("AAPL", srv.Client())
+	if _, err := ticker.Info(); err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+
+	srv.ExpireSession()
+	if _, err := ticker.Info(); err != nil {
+		t.Fatalf("Expected the client to renew its crumb, got %v", err)
+	}
+	if n := srv.Requests(EndpointCrumb); n != 2 {
+		t.Errorf("Expected 2 crumb requests, got %d", n)
+	}
+
+	srv.ExpireSession()
+	srv.FailNext(EndpointCrumb, http.StatusInternalServerError, 1)
+	if _, err := ticker.Info(); err != nil {
+		t.Fatalf("Expected the client to recover from a failed crumb request, got %v", err)
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ticker := yfa.NewTickerWithClient("AAPL", client)
+	if _, err := ticker.Info(); err != nil {
+		t.Fatalf("Info returned error: %v", err)
+	}
+
+	srv.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := ticker.InfoContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestServerEvents(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddSymbol(Symbol{
+		Symbol:    "MSFT",
+		Bars:      GenerateBars(testStart, 24*time.Hour, 20, 420),
+		Dividends: []yfa.Dividend{{Date: testStart.AddDate(0, 0, 7), Amount: 0.83}, {Date: testStart.AddDate(-1, 0, 0), Amount: 0.75}},
+		Splits:    []yfa.Split{{Date: testStart.AddDate(0, 0, 14), Numerator: 2, Denominator: 1}},
+	})
+
+	series, err := yfa.NewTickerWithClient("MSFT", srv.Client()).HistorySeries(yfa.HistoryQuery{Range: "max"})
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if len(series.Dividends) != 1 || series.Dividends[0].Amount != 0.83 {
+		t.Errorf("Expected only the dividend within the history, got %+v", series.Dividends)
+	}
+	if len(series.Splits) != 1 || series.Splits[0].Ratio != "2:1" {
+		t.Errorf("Expected a 2:1 split, got %+v", series.Splits)
+	}
+}
+
+func TestServerMissingBars(t *testing.T) {
+	srv := newTestServer(t)
+	bars := GenerateBars(testStart, time.Minute, 10, 190)
+	bars[4].Missing = true
+	srv.AddSymbol(Symbol{Symbol: "HALT", Bars: bars})
+
+	ticker := yfa.NewTickerWithClient("HALT", srv.Client())
+	series, err := ticker.HistorySeries(yfa.HistoryQuery{Range: "1d", Interval: "1m", Missing: yfa.MissingForwardFill})
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if series.Len() != 10 || series.Missing.Filled != 1 {
+		t.Fatalf("Expected 10 bars with one filled, got %d and %+v", series.Len(), series.Missing)
+	}
+	if series.Bars[4].Close != bars[3].Close || series.Bars[4].Volume != 0 {
+		t.Errorf("Expected the missing bar to carry the previous close without volume, got %+v", series.Bars[4].PriceData)
+	}
+}
+
+func TestServerPrePost(t *testing.T) {
+	srv := newTestServer(t)
+	// Half-hourly bars from 4:00 to 19:30 New York time, recent enough to be within Yahoo's retention.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No timezone database: %v", err)
+	}
+	y, m, d := time.Now().In(ny).AddDate(0, 0, -3).Date()
+	start := time.Date(y, m, d, 4, 0, 0, 0, ny)
+	srv.AddSymbol(Symbol{Symbol: "EXT", Bars: GenerateBars(start, 30*time.Minute, 32, 100)})
+	ticker := yfa.NewTickerWithClient("EXT", srv.Client())
+	query := yfa.HistoryQuery{Start: start, End: start.Add(24 * time.Hour), Interval: "30m"}
+
+	regular, err := ticker.HistorySeries(query)
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	if regular.Len() != 13 || regular.Sessions(yfa.SessionRegular).Len() != 13 {
+		t.Errorf("Expected 13 regular bars without includePrePost, got %d", regular.Len())
+	}
+
+	query.IncludePrePost = true
+	extended, err := ticker.HistorySeries(query)
+	if err != nil {
+		t.Fatalf("HistorySeries returned error: %v", err)
+	}
+	counts := map[yfa.Session]int{}
+	for _, bar := range extended.Bars {
+		counts[bar.Session]++
+	}
+	if counts[yfa.SessionPre] != 11 || counts[yfa.SessionRegular] != 13 || counts[yfa.SessionPost] != 8 {
+		t.Errorf("Expected 11 pre, 13 regular and 8 post-market bars, got %v", counts)
+	}
+}
+
+func TestServerResampledHistory(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddSymbol(Symbol{Symbol: "FIVE", Bars: GenerateBars(testStart, 5*time.Minute, 78, 100)}) // A regular session
+	series, err := yfa.NewTickerWithClient("FIVE", srv.Client()).ResampledHistory(yfa.HistoryQuery{Range: "1d"}, yfa.Minutes(30))
+	if err != nil {
+		t.Fatalf("ResampledHistory returned error: %v", err)
+	}
+	if series.Len() != 13 || series.Interval != "30m" {
+		t.Fatalf("Expected 13 30m bars, got %d %s bars", series.Len(), series.Interval)
+	}
+	first := series.Bars[0]
+	if !first.Time.Equal(testStart) || first.Volume <= 0 || first.Session != yfa.SessionRegular {
+		t.Errorf("Unexpected first bar %+v", first)
+	}
+}